@@ -0,0 +1,242 @@
+package lfdoverfitting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+//CurvaAprendizado experimento da curva de aprendizado de uma hipótese de grau Grau:
+//E_in e E_out esperados em função do tamanho N da base, para alvos de grau Qf e ruído NovoRuido(Sigma).
+//Cada uma das Bases repetições sorteia um alvo como GeraBase; com Aninhadas as bases de todos os N
+//são prefixos de uma única amostra de tamanho max(Ns), e as curvas de cada repetição ficam suaves.
+type CurvaAprendizado struct {
+	Qf            int
+	Ns            []int
+	Sigma         float64
+	Grau          int
+	Regularizacao Regularizacao //weight decay opcional da hipótese
+
+	Bases     int  //repetições por N
+	Aninhadas bool //bases de tamanhos crescentes como prefixos de uma mesma amostra
+
+	NovoRuido func(sigma float64) Ruido //modelo de ruído; nil usa o gaussiano
+	Semente   int64                     //a repetição j usa DerivaSemente(Semente, j[, i]) para o alvo e a base do i-ésimo N
+}
+
+//PontoAprendizado médias e erros padrão de E_in e E_out para um tamanho de base.
+//Os dois erros estão na escala de Ruido.Energia(): E_out = E_x[(g(x) - f(x))²] + sigma²,
+//de modo que as curvas convergem para sigma² mais o viés da hipótese.
+type PontoAprendizado struct {
+	N         int
+	Ein       float64
+	ErroEin   float64 //erro padrão da média de E_in
+	Eout      float64
+	ErroEout  float64 //erro padrão da média de E_out
+	Execucoes int
+}
+
+//Aprendizado pontos da curva de aprendizado, na ordem de CurvaAprendizado.Ns
+type Aprendizado []PontoAprendizado
+
+//Executa o experimento
+func (c CurvaAprendizado) Executa() (Aprendizado, error) {
+	if len(c.Ns) == 0 {
+		return nil, errors.New("curva de aprendizado: nenhum tamanho de base")
+	}
+	if c.Bases < 2 {
+		return nil, fmt.Errorf("curva de aprendizado: bases = %d deve ser pelo menos 2", c.Bases)
+	}
+	maior := 0
+	for _, n := range c.Ns {
+		if n < 1 {
+			return nil, fmt.Errorf("curva de aprendizado: N = %d deve ser positivo", n)
+		}
+		if n > maior {
+			maior = n
+		}
+	}
+	novoRuido := c.NovoRuido
+	if novoRuido == nil {
+		novoRuido = ruidoGaussiano
+	}
+	ruido := novoRuido(c.Sigma)
+
+	eins := make([][]float64, len(c.Ns))
+	eouts := make([][]float64, len(c.Ns))
+	for j := 0; j < c.Bases; j++ {
+		rng := NovoRNG(DerivaSemente(c.Semente, j))
+		alvo := GeraAlvo(rng, c.Qf)
+		var amostra Base
+		if c.Aninhadas {
+			amostra = AmostraBase(rng, alvo, maior, ruido)
+		}
+		for i, n := range c.Ns {
+			b := amostra
+			if c.Aninhadas {
+				b.X, b.Y = amostra.X[:n], amostra.Y[:n]
+			} else {
+				b = AmostraBase(NovoRNG(DerivaSemente(c.Semente, j, i)), alvo, n, ruido)
+			}
+			a, err := AjustaRegularizado(b, c.Grau, c.Regularizacao)
+			if err != nil {
+				return nil, fmt.Errorf("N = %d: %v", n, err)
+			}
+			eins[i] = append(eins[i], a.Residuo*a.Residuo/float64(n))
+			eouts[i] = append(eouts[i], EoutLegendre(alvo.A, a.Legendre)/2+ruido.Energia())
+		}
+	}
+
+	pts := make(Aprendizado, len(c.Ns))
+	for i, n := range c.Ns {
+		pts[i] = PontoAprendizado{N: n, Execucoes: c.Bases}
+		pts[i].Ein, pts[i].ErroEin = agrega(eins[i])
+		pts[i].Eout, pts[i].ErroEout = agrega(eouts[i])
+	}
+	return pts, nil
+}
+
+//EscreveCSV grava uma linha por N com as colunas n,ein,erro_ein,eout,erro_eout,execucoes
+func (a Aprendizado) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"n", "ein", "erro_ein", "eout", "erro_eout", "execucoes"}); err != nil {
+		return err
+	}
+	for _, p := range a {
+		linha := []string{
+			strconv.Itoa(p.N),
+			strconv.FormatFloat(p.Ein, 'g', -1, 64),
+			strconv.FormatFloat(p.ErroEin, 'g', -1, 64),
+			strconv.FormatFloat(p.Eout, 'g', -1, 64),
+			strconv.FormatFloat(p.ErroEout, 'g', -1, 64),
+			strconv.Itoa(p.Execucoes),
+		}
+		if err := cw.Write(linha); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//LeAprendizado lê a curva gravada por Aprendizado.EscreveCSV
+func LeAprendizado(r io.Reader) (Aprendizado, error) {
+	linhas, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(linhas) == 0 {
+		return nil, errors.New("curva de aprendizado: arquivo vazio")
+	}
+	var a Aprendizado
+	for i, l := range linhas[1:] {
+		if len(l) != 6 {
+			return nil, fmt.Errorf("curva de aprendizado: linha %d tem %d colunas, esperava 6", i+2, len(l))
+		}
+		var p PontoAprendizado
+		if p.N, err = strconv.Atoi(l[0]); err != nil {
+			return nil, fmt.Errorf("curva de aprendizado: linha %d: %v", i+2, err)
+		}
+		for k, dst := range []*float64{&p.Ein, &p.ErroEin, &p.Eout, &p.ErroEout} {
+			if *dst, err = strconv.ParseFloat(l[k+1], 64); err != nil {
+				return nil, fmt.Errorf("curva de aprendizado: linha %d: %v", i+2, err)
+			}
+		}
+		if p.Execucoes, err = strconv.Atoi(l[5]); err != nil {
+			return nil, fmt.Errorf("curva de aprendizado: linha %d: %v", i+2, err)
+		}
+		a = append(a, p)
+	}
+	return a, nil
+}
+
+//Desenha as curvas de E_in e E_out em função de N, cada uma com a faixa de ± um erro padrão.
+//Sigma > 0 acrescenta a linha horizontal sigma², o limite das duas curvas quando a hipótese contém o alvo.
+//Usa Titulo, Largura, Altura, YMin e YMax de gr.
+func (a Aprendizado) Desenha(path string, gr Grafico, sigma float64) error {
+	if err := formatoSuportado(path); err != nil {
+		return err
+	}
+	if len(a) == 0 {
+		return errors.New("curva de aprendizado: nenhum ponto")
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		return err
+	}
+	p.Title.Text = gr.Titulo
+	if p.Title.Text == "" {
+		p.Title.Text = "Curva de aprendizado"
+	}
+	p.X.Label.Text = "N"
+	p.Y.Label.Text = "erro esperado"
+	p.Legend.Top = true
+
+	curvas := []struct {
+		nome  string
+		valor func(p PontoAprendizado) (float64, float64)
+	}{
+		{"E_in", func(p PontoAprendizado) (float64, float64) { return p.Ein, p.ErroEin }},
+		{"E_out", func(p PontoAprendizado) (float64, float64) { return p.Eout, p.ErroEout }},
+	}
+	for i, c := range curvas {
+		linha := make(plotter.XYs, len(a))
+		faixa := make(plotter.XYs, 2*len(a))
+		for k, pt := range a {
+			media, erro := c.valor(pt)
+			if math.IsNaN(erro) {
+				erro = 0
+			}
+			x := float64(pt.N)
+			linha[k].X, linha[k].Y = x, media
+			//a faixa percorre a borda superior da esquerda para a direita e volta pela inferior
+			faixa[k].X, faixa[k].Y = x, media+erro
+			faixa[2*len(a)-1-k].X, faixa[2*len(a)-1-k].Y = x, media-erro
+		}
+
+		cor := plotutil.Color(i)
+		f, err := plotter.NewPolygon(faixa)
+		if err != nil {
+			return err
+		}
+		r, g, b, _ := cor.RGBA()
+		f.Color = color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 64}
+		f.LineStyle.Width = 0
+		p.Add(f)
+
+		l, err := plotter.NewLine(linha)
+		if err != nil {
+			return err
+		}
+		l.LineStyle.Width = vg.Points(1.5)
+		l.LineStyle.Color = cor
+		p.Add(l)
+		p.Legend.Add(c.nome, l)
+	}
+
+	if sigma > 0 {
+		nivel := plotter.XYs{{X: float64(a[0].N), Y: sigma * sigma}, {X: float64(a[len(a)-1].N), Y: sigma * sigma}}
+		l, err := plotter.NewLine(nivel)
+		if err != nil {
+			return err
+		}
+		l.LineStyle.Dashes = plotutil.Dashes(1)
+		p.Add(l)
+		p.Legend.Add("sigma²", l)
+	}
+
+	if gr.YMin != gr.YMax {
+		p.Y.Min, p.Y.Max = gr.YMin, gr.YMax
+	}
+	return p.Save(gr.tamanho(gr.Largura), gr.tamanho(gr.Altura), path)
+}
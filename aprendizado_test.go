@@ -0,0 +1,40 @@
+package lfdoverfitting
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCurvaAprendizado(t *testing.T) {
+	c := CurvaAprendizado{Qf: 2, Ns: []int{5, 20, 80}, Sigma: 0.5, Grau: 2, Bases: 300, Aninhadas: true, Semente: 7}
+	a, err := c.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//a hipótese contém o alvo: E_in cresce e E_out cai em direção a sigma², um de cada lado
+	for i, p := range a {
+		if p.Ein >= 0.25 || p.Eout <= 0.25 {
+			t.Errorf("N = %d: ein = %v, eout = %v; want ein < 0.25 < eout", p.N, p.Ein, p.Eout)
+		}
+		if i > 0 && (p.Ein <= a[i-1].Ein || p.Eout >= a[i-1].Eout) {
+			t.Errorf("N = %d: ein = %v, eout = %v não são monótonos após %+v", p.N, p.Ein, p.Eout, a[i-1])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := a.EscreveCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lida, err := LeAprendizado(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lida) != len(a) {
+		t.Fatalf("len(lida) = %d; want %d", len(lida), len(a))
+	}
+	for i := range a {
+		if lida[i] != a[i] {
+			t.Errorf("lida[%d] = %+v; want %+v", i, lida[i], a[i])
+		}
+	}
+}
@@ -1,28 +1,27 @@
-package main
+package lfdoverfitting
 
 import (
 	"math"
-	"math/big"
 	"math/rand"
 )
 
+//Alvo função alvo f(x) = sum_{q=0}^{qf} ( a_q * Legendre_q(x) ), guardada e avaliada na base de legendre
+type Alvo struct {
+	A []float64 //constantes a's normalizadas
+}
+
 //Base gerada pela soma de funções de legendre. X inputs, Y outputs, A coefs.
 type Base struct {
-	A []float64 //constantes a's normalizadas
-	F []float64 // coeficientes polinomio f = sum(a_q * Legendre_q(x))
+	Alvo
 	X []float64 //vetor de entrada
 	Y []float64 //saida
+
+	Ruido Ruido //modelo de ruído estocástico somado em Y
 }
 
-//Gera uma base com n instancias baseado na função alvo gerada pelo somatorio de polinômios de legendre + ruido
-//y_n = f(x_n) + sigma * e_n
-//f(x) = sum_{q=0}^{qf} ( a_q * Legendre_q(x) )
-func geraBase(qf int, n int, sigma float64) Base {
-	var b = Base{}
-	b.A = make([]float64, qf+1)
-	b.F = make([]float64, qf+1)
-	b.X = make([]float64, n)
-	b.Y = make([]float64, n)
+//GeraAlvo sorteia uma função alvo de grau qf com coeficientes normalizados para que E[f²] = 1
+func GeraAlvo(rng *rand.Rand, qf int) Alvo {
+	a := make([]float64, qf+1)
 
 	//calcula fator de normalização
 	c := 0.0
@@ -33,41 +32,57 @@ func geraBase(qf int, n int, sigma float64) Base {
 
 	//gera coeficientes
 	for j := 0; j <= qf; j++ {
-		b.A[j] = r(true) / c
+		a[j] = r(rng, true) / c
 	}
 
-	//calcula coeficientes do polinomio f
-	for i := 0; i <= qf; i++ {
-		auxSoma := new(big.Float).SetPrec(prec).SetFloat64(0.0)
-		for j := 0; j <= qf; j++ {
-			auxMul := new(big.Float).SetPrec(prec).Set(MatrizLegendre[j][i])
-			auxMul.Mul(new(big.Float).SetPrec(prec).SetFloat64(b.A[j]), auxMul)
-			auxSoma.Add(auxSoma, auxMul)
-		}
-		b.F[i], _ = auxSoma.Float64()
-	}
+	return NovoAlvo(a)
+}
+
+//NovoAlvo alvo f(x) = sum_q ( a[q] * Legendre_q(x) ) com os coeficientes a dados
+func NovoAlvo(a []float64) Alvo {
+	return Alvo{A: a}
+}
+
+//Avalia f(x) pela recorrência de três termos (Clenshaw), estável para Qf na casa das centenas
+func (a Alvo) Avalia(x float64) float64 {
+	return AvaliaLegendre(a.A, x)
+}
+
+//Monomial f na base de monômios, calculado com a LegendreBasis. Para Qf alto os coeficientes
+//crescem e se cancelam e a avaliação em float64 perde a precisão; serve apenas para exportar o alvo.
+func (a Alvo) Monomial() (Poly, error) {
+	return PolyLegendre(a.A)
+}
+
+//GeraBase gera uma base com n instancias baseado na função alvo gerada pelo somatorio de polinômios de legendre + ruido
+//y_n = f(x_n) + e_n, com e_n sorteado pelo modelo de ruído
+//f(x) = sum_{q=0}^{qf} ( a_q * Legendre_q(x) )
+func GeraBase(rng *rand.Rand, qf int, n int, ruido Ruido) Base {
+	return AmostraBase(rng, GeraAlvo(rng, qf), n, ruido)
+}
+
+//AmostraBase sorteia n instancias x uniformes em [-1;1] e y = f(x) + e de um alvo já construído
+func AmostraBase(rng *rand.Rand, alvo Alvo, n int, ruido Ruido) Base {
+	var b = Base{Alvo: alvo, Ruido: ruido}
+	b.X = make([]float64, n)
+	b.Y = make([]float64, n)
 
 	//gera vetor de entrada e saida
 	for i := 0; i < n; i++ {
-		b.X[i] = r(false)
-		f := 0.0
-		for j := 0; j <= qf; j++ {
-			f += b.F[j] * math.Pow(b.X[i], float64(j))
-		}
-
-		b.Y[i] = f //+ sigma*r(true)
+		b.X[i] = r(rng, false)
+		b.Y[i] = alvo.Avalia(b.X[i]) + ruido.Amostra(rng, b.X[i])
 	}
 
 	return b
 }
 
-//Gera um número randômico
+//Gera um número randômico a partir do gerador rng
 //norm = false ==> distribuição uniforme [-1;1]
 //norm = true ==> distribuição normal padrão
-func r(norm bool) float64 {
+func r(rng *rand.Rand, norm bool) float64 {
 	if norm {
-		return rand.NormFloat64()
+		return rng.NormFloat64()
 	}
 
-	return -1.0 + 2.0*rand.Float64()
+	return -1.0 + 2.0*rng.Float64()
 }
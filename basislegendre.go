@@ -0,0 +1,80 @@
+package lfdoverfitting
+
+import (
+	"fmt"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+//GrauMaximoLegendre maior grau aceito pela LegendreBasis. Os coeficientes do meio de P_k crescem
+//como (1+sqrt(2))^k, bem mais rápido que o líder (2k)! / (2^k (k!)²) ~ 2^k / sqrt(pi k), e o primeiro
+//deles transborda float64 no grau 814.
+const GrauMaximoLegendre = 800
+
+//LegendreBasis coeficientes de monômios dos polinômios de legendre, C[k][i] de x^i em P_k, com um grau máximo.
+//Os coeficientes vêm de uma legendre.TabelaFloat64, que os converte do racional exato sob demanda e pode
+//ser compartilhada entre goroutines.
+type LegendreBasis struct {
+	tabela *legendre.TabelaFloat64
+	limite int
+}
+
+//NovaLegendreBasis base vazia que aceita graus até limite; limite <= 0 ou acima de GrauMaximoLegendre
+//usa GrauMaximoLegendre
+func NovaLegendreBasis(limite int) *LegendreBasis {
+	return novaLegendreBasis(legendre.NovaTabelaFloat64(), limite)
+}
+
+func novaLegendreBasis(t *legendre.TabelaFloat64, limite int) *LegendreBasis {
+	if limite <= 0 || limite > GrauMaximoLegendre {
+		limite = GrauMaximoLegendre
+	}
+	return &LegendreBasis{tabela: t, limite: limite}
+}
+
+//basePadrao base de PolyLegendre e Alvo.Monomial, sobre a mesma tabela de legendre.Serie.Monomios
+var basePadrao = novaLegendreBasis(legendre.TabelaMonomios(), GrauMaximoLegendre)
+
+//Limite maior grau aceito
+func (b *LegendreBasis) Limite() int {
+	return b.limite
+}
+
+//Grau maior grau já calculado; -1 para a base vazia
+func (b *LegendreBasis) Grau() int {
+	return b.tabela.Grau()
+}
+
+//Coeficientes C[k][0..k] de P_k. O slice é compartilhado com a base e não deve ser alterado.
+func (b *LegendreBasis) Coeficientes(k int) ([]float64, error) {
+	if err := b.Cresce(k); err != nil {
+		return nil, err
+	}
+	return b.tabela.Coeficientes(k)
+}
+
+//Cresce calcula os graus até n, se ainda faltarem
+func (b *LegendreBasis) Cresce(n int) error {
+	if n < 0 {
+		return fmt.Errorf("base de legendre: grau %d negativo", n)
+	}
+	if n > b.limite {
+		return fmt.Errorf("base de legendre: grau %d acima do máximo suportado %d", n, b.limite)
+	}
+	return b.tabela.Cresce(n)
+}
+
+//Monomial converte sum_k ( a[k] * Legendre_k(x) ) em coeficientes de monômios
+func (b *LegendreBasis) Monomial(a []float64) (Poly, error) {
+	if len(a) == 0 {
+		return Poly{}, nil
+	}
+	if err := b.Cresce(len(a) - 1); err != nil {
+		return nil, err
+	}
+	f, err := b.tabela.Monomios(legendre.Serie(a))
+	if err != nil {
+		return nil, err
+	}
+	return Poly(f), nil
+}
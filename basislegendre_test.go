@@ -0,0 +1,111 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+func TestLegendreBasis(t *testing.T) {
+	b := NovaLegendreBasis(300)
+	if g := b.Grau(); g != -1 {
+		t.Errorf("grau da base vazia: got %d; want -1", g)
+	}
+
+	//várias goroutines pedindo graus diferentes ao mesmo tempo; rode com -race
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k <= 300; k += 37 {
+				c, err := b.Coeficientes(k)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				//P_k(1) = 1; acima do grau 20 a soma já sofre cancelamento
+				soma := 0.0
+				for _, ci := range c {
+					soma += ci
+				}
+				if k <= 20 && math.Abs(soma-1) > 1e-9 {
+					t.Errorf("P_%d(1) = %v; want 1", k, soma)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if _, err := b.Coeficientes(301); err == nil {
+		t.Error("grau acima do limite deveria falhar")
+	}
+	if _, err := b.Coeficientes(-1); err == nil {
+		t.Error("grau negativo deveria falhar")
+	}
+
+	//alvo de grau acima dos 100 da antiga MatrizLegendre
+	a := GeraAlvo(NovoRNG(4), 150)
+	f, err := a.Monomial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f) != 151 {
+		t.Errorf("len(f) = %d; want 151", len(f))
+	}
+	//a conversão do pacote legendre usa a mesma tabela exata
+	m, err := legendre.Serie(a.A).Monomios()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range f {
+		if m[i] != f[i] {
+			t.Errorf("x^%d: Serie.Monomios = %v; LegendreBasis = %v", i, m[i], f[i])
+		}
+	}
+	//no limite todos os coeficientes são finitos; a tabela exata falha a partir do primeiro transbordamento
+	c, err := basePadrao.Coeficientes(GrauMaximoLegendre)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ci := range c {
+		if math.IsInf(ci, 0) || math.IsNaN(ci) {
+			t.Fatalf("coeficiente de x^%d em P_%d = %v", i, GrauMaximoLegendre, ci)
+		}
+	}
+	if _, err := basePadrao.Coeficientes(GrauMaximoLegendre + 1); err == nil {
+		t.Error("grau acima de GrauMaximoLegendre deveria falhar")
+	}
+	if _, err := legendre.NovaTabelaRacional().Float64(814); err == nil {
+		t.Error("P_814 deveria transbordar float64")
+	}
+	if _, err := PolyLegendre(make([]float64, GrauMaximoLegendre+2)); err == nil {
+		t.Error("PolyLegendre acima de GrauMaximoLegendre deveria falhar")
+	}
+	if p, err := PolyLegendre(nil); err != nil || len(p) != 0 {
+		t.Errorf("PolyLegendre(nil) = %v, %v; want vazio", p, err)
+	}
+}
+
+func TestBasePadraoCompartilhaTabela(t *testing.T) {
+	//PolyLegendre e Serie.Monomios leem as mesmas linhas já convertidas, sem um segundo cache
+	if _, err := legendre.Serie(make([]float64, 61)).Monomios(); err != nil {
+		t.Fatal(err)
+	}
+	if g := basePadrao.Grau(); g < 60 {
+		t.Errorf("grau da base padrão = %d depois de Serie.Monomios até o grau 60", g)
+	}
+	c, err := basePadrao.Coeficientes(60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := legendre.TabelaMonomios().Coeficientes(60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &c[0] != &d[0] {
+		t.Error("a base padrão e legendre.TabelaMonomios guardam cópias diferentes de P_60")
+	}
+}
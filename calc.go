@@ -1,27 +1,65 @@
-package main
+package lfdoverfitting
 
-func esp(f []float64, g []float64) float64 {
-	return intMinus1To1Poly(mulPoly(f, g))
-}
+import (
+	"math"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
 
-//Multiplica f(x) * g(x)
-//f e g são os indices dos polinômios. Ex.: f[0]x^0 + f[1]x^1 + ... + f[n]x^n.
-func mulPoly(f []float64, g []float64) []float64 {
-	fg := make([]float64, (len(f)-1)*(len(g)-1)+1)
-	for i := 0; i < len(f); i++ {
-		for j := 0; j < len(g); j++ {
-			fg[i+j] += f[i] * g[j]
+//EoutLegendre erro fora da amostra de g em relação ao alvo f: Integral{-1^1}( (g(x) - f(x))^2 ).
+//a e g são coeficientes na base de legendre, de graus quaisquer; pela ortogonalidade
+//Integral{-1^1}( L_q(x)^2 ) = 2/(2q+1) e o erro é sum_q 2/(2q+1) (a_q - g_q)^2, sem cancelamento.
+func EoutLegendre(a []float64, g []float64) float64 {
+	result := 0.0
+	for q := 0; q < len(a) || q < len(g); q++ {
+		d := 0.0
+		if q < len(a) {
+			d = a[q]
+		}
+		if q < len(g) {
+			d -= g[q]
 		}
+		result += 2 * d * d / float64(2*q+1)
 	}
-	return fg
+	return result
 }
 
-// Integral{-1^1}( f(x) )
-// f é o vetor de indices de um polinômio. Ex.: f[0]x^0 + f[1]x^1 + ... + f[n]x^n.
-func intMinus1To1Poly(f []float64) float64 {
-	result := 0.0
-	for i := 0; i < len(f); i += 2 {
-		result += 2 * f[i] / float64(i+1)
+//EoutEsperado erro fora da amostra de g na mesma escala de Ein: E_{x,y}[(g(x) - y)²] com x uniforme
+//em [-1;1] e y = f(x) + ruído, isto é, EoutLegendre(b.A, g)/2 + a energia do ruído da base (0 sem ruído).
+//É a convenção de ViesVariancia e CurvaAprendizado. NaN quando a base não tem alvo.
+func EoutEsperado(b Base, g []float64) float64 {
+	if len(b.A) == 0 {
+		return math.NaN()
 	}
-	return result
+	e := EoutLegendre(b.A, g) / 2
+	if b.Ruido != nil {
+		e += b.Ruido.Energia()
+	}
+	return e
+}
+
+//Eout erro fora da amostra de g em relação ao alvo f: Integral{-1^1}( (g(x) - f(x))^2 )
+//f e g são polinômios na base de monômios.
+//Sofre cancelamento quando f e g são próximos; serve como conferência de EoutLegendre.
+func Eout(f Poly, g Poly) float64 {
+	return esp(g, g) - 2*esp(g, f) + esp(f, f)
+}
+
+//esp Integral{-1^1}( f(x) * g(x) )
+func esp(f Poly, g Poly) float64 {
+	return f.Multiplica(g).Integral(-1, 1)
+}
+
+//EoutFuncao erro fora da amostra Integral{-1^1}( (g(x) - f(x))^2 ) de funções quaisquer, pela quadratura
+//de Gauss-Legendre de ordem ordem. É exato até o arredondamento quando f e g são polinômios de grau
+//menor que ordem; para modelos não polinomiais (predições limitadas, splines) aumente ordem até estabilizar.
+func EoutFuncao(f func(x float64) float64, g func(x float64) float64, ordem int) (float64, error) {
+	q, err := legendre.NovaQuadratura(ordem)
+	if err != nil {
+		return 0, err
+	}
+	return q.Integra(func(x float64) float64 {
+		d := g(x) - f(x)
+		return d * d
+	}, -1, 1), nil
 }
@@ -0,0 +1,54 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEoutLegendreConfereMonomios(t *testing.T) {
+	rng := NovoRNG(8)
+	for _, graus := range [][2]int{{0, 0}, {0, 3}, {1, 10}, {5, 2}, {10, 10}, {3, 15}} {
+		f, g := GeraAlvo(rng, graus[0]), GeraAlvo(rng, graus[1])
+		fm, err := f.Monomial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		gm, err := g.Monomial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		//a fórmula de monômios já perde dígitos por cancelamento no grau 15
+		got, want := EoutLegendre(f.A, g.A), Eout(fm, gm)
+		if math.Abs(got-want) > 1e-6*math.Max(1, want) {
+			t.Errorf("graus %v: got %v; want %v", graus, got, want)
+		}
+	}
+}
+
+func TestEoutFuncaoConfereLegendre(t *testing.T) {
+	rng := NovoRNG(9)
+	f, g := GeraAlvo(rng, 40), GeraAlvo(rng, 10)
+	//(g - f)² tem grau 80 e a regra de ordem 41 o integra exatamente
+	got, err := EoutFuncao(f.Avalia, g.Avalia, 41)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := EoutLegendre(f.A, g.A); math.Abs(got-want) > 1e-13*want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestEoutEsperado(t *testing.T) {
+	b := GeraBase(NovoRNG(10), 6, 30, ruidoGaussiano(0.5))
+	//a hipótese igual ao alvo só erra pelo ruído
+	if got := EoutEsperado(b, b.A); math.Abs(got-0.25) > 1e-15 {
+		t.Errorf("g = f: got %v; want sigma² = 0.25", got)
+	}
+	g := GeraAlvo(NovoRNG(11), 3).A
+	if got, want := EoutEsperado(b, g), EoutLegendre(b.A, g)/2+0.25; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if got := EoutEsperado(Base{}, g); !math.IsNaN(got) {
+		t.Errorf("sem alvo: got %v; want NaN", got)
+	}
+}
@@ -0,0 +1,100 @@
+package lfdoverfitting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+//Checkpoint arquivo JSON lines com as células já concluídas de um ou mais sweeps.
+//Cada linha identifica o sweep pela assinatura (grade, repetições, graus, semente e ruído),
+//de modo que retomar com outra configuração simplesmente recalcula tudo.
+type Checkpoint struct {
+	mu         sync.Mutex
+	arquivo    *os.File
+	concluidas map[string]map[int]Resultado
+}
+
+//linhaCheckpoint uma célula concluída
+type linhaCheckpoint struct {
+	Assinatura string    `json:"assinatura"`
+	Celula     int       `json:"celula"`
+	Resultado  Resultado `json:"resultado"`
+}
+
+//AbreCheckpoint lê as células concluídas de path, se existir, e abre o arquivo para acrescentar novas.
+//Uma última linha incompleta (processo morto durante a escrita) é descartada.
+func AbreCheckpoint(path string) (*Checkpoint, error) {
+	arquivo, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	c := &Checkpoint{arquivo: arquivo, concluidas: map[string]map[int]Resultado{}}
+
+	var valido int64 //bytes até o fim da última linha válida
+	leitor := bufio.NewReader(arquivo)
+	for {
+		linha, err := leitor.ReadBytes('\n')
+		if err == io.EOF {
+			break //sem '\n' final a linha está incompleta
+		}
+		if err != nil {
+			arquivo.Close()
+			return nil, err
+		}
+		var l linhaCheckpoint
+		if err := json.Unmarshal(bytes.TrimSpace(linha), &l); err != nil {
+			arquivo.Close()
+			return nil, fmt.Errorf("%s: linha corrompida após %d bytes: %v", path, valido, err)
+		}
+		if c.concluidas[l.Assinatura] == nil {
+			c.concluidas[l.Assinatura] = map[int]Resultado{}
+		}
+		c.concluidas[l.Assinatura][l.Celula] = l.Resultado
+		valido += int64(len(linha))
+	}
+
+	if err := arquivo.Truncate(valido); err != nil {
+		arquivo.Close()
+		return nil, err
+	}
+	if _, err := arquivo.Seek(valido, io.SeekStart); err != nil {
+		arquivo.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+//Retoma configura o sweep para pular as células já gravadas e gravar cada nova célula concluída
+func (c *Checkpoint) Retoma(s *Sweep) {
+	assinatura := s.assinatura()
+	s.Concluidas = c.concluidas[assinatura]
+	s.AoConcluir = func(i int, r Resultado) error {
+		return c.grava(linhaCheckpoint{Assinatura: assinatura, Celula: i, Resultado: r})
+	}
+}
+
+//grava acrescenta uma linha e força a escrita em disco
+func (c *Checkpoint) grava(l linhaCheckpoint) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.arquivo.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return c.arquivo.Sync()
+}
+
+//Close fecha o arquivo do checkpoint
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.arquivo.Close()
+}
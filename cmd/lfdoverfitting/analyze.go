@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//analyze importa uma base externa x,y e compara graus de hipótese por Ein, holdout e validação cruzada
+func analyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	dados := fs.String("data", "", "arquivo CSV com a base externa")
+	colX := fs.String("x", "", "nome da coluna de entrada (vazio = primeira coluna)")
+	colY := fs.String("y", "", "nome da coluna de saída (vazio = segunda coluna)")
+	graus := fs.String("degrees", "0,1,2,3,4,5,6,7,8,9,10", "graus das hipóteses, separados por vírgula")
+	k := fs.Int("holdout", 0, "pontos no conjunto de validação (0 = N/5)")
+	folds := fs.Int("folds", 10, "partes da validação cruzada")
+	seed := fs.Int64("seed", 0, "semente do sorteio das partições (0 = derivada do relógio)")
+	saida := fs.String("o", "", "arquivo CSV do relatório (vazio = saída padrão)")
+	fs.Parse(args)
+
+	file, err := os.Open(*dados)
+	if err != nil {
+		return err
+	}
+	b, err := lfdoverfitting.ImportaCSV(file, *colX, *colY)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %v", *dados, err)
+	}
+	b, escala, err := lfdoverfitting.Reescala(b)
+	if err != nil {
+		return err
+	}
+	gs, err := inteiros(*graus)
+	if err != nil {
+		return err
+	}
+	if *k == 0 {
+		*k = len(b.X) / 5
+	}
+
+	rng := lfdoverfitting.NovoRNG(semente(*seed))
+	a, err := lfdoverfitting.AnalisaGraus(rng, b, gs, *k, *folds)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d pontos, x reescalado de [%v; %v] para [-1; 1]\n", len(b.X), escala.Min, escala.Max)
+	if a.Selecionado < 0 {
+		fmt.Fprintln(os.Stderr, "nenhum grau selecionado: todos os erros de validação cruzada são NaN")
+	} else {
+		fmt.Fprintf(os.Stderr, "grau selecionado por validação cruzada: %d\n", a.Selecionado)
+	}
+
+	if *saida == "" {
+		return a.EscreveCSV(os.Stdout)
+	}
+	return cria(*saida, a.EscreveCSV)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//biasvar decompõe o Eout esperado de cada grau de hipótese em viés, variância e ruído
+func biasvar(args []string) error {
+	fs := flag.NewFlagSet("biasvar", flag.ExitOnError)
+	qf := fs.Int("qf", 10, "grau do alvo")
+	n := fs.Int("n", 40, "tamanho de cada base")
+	sigma := fs.Float64("sigma", 0.5, "desvio do ruído gaussiano")
+	graus := fs.String("degrees", "2,10", "graus das hipóteses, separados por vírgula")
+	lambda := fs.Float64("lambda", 0, "weight decay das hipóteses (0 = sem regularização)")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	bases := fs.Int("datasets", 1000, "bases sorteadas por alvo")
+	alvos := fs.Int("targets", 1, "alvos sorteados; o resultado é a média sobre eles")
+	seed := fs.Int64("seed", 0, "semente mãe dos geradores aleatórios (0 = derivada do relógio)")
+	saida := fs.String("o", "", "arquivo CSV do relatório (vazio = saída padrão)")
+	fs.Parse(args)
+
+	gs, err := inteiros(*graus)
+	if err != nil {
+		return err
+	}
+	v := lfdoverfitting.ViesVariancia{
+		Qf:            *qf,
+		N:             *n,
+		Sigma:         *sigma,
+		Regularizacao: lfdoverfitting.Regularizacao{Lambda: *lambda, Penalidade: *penalidade},
+		Bases:         *bases,
+		Alvos:         *alvos,
+		Semente:       semente(*seed),
+	}
+	var ds lfdoverfitting.Decomposicoes
+	for _, g := range gs {
+		v.Grau = g
+		d, err := v.Executa()
+		if err != nil {
+			return fmt.Errorf("grau %d: %v", g, err)
+		}
+		ds = append(ds, d)
+	}
+
+	if *saida == "" {
+		return ds.EscreveCSV(os.Stdout)
+	}
+	return cria(*saida, ds.EscreveCSV)
+}
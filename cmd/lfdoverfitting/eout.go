@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//eout avalia Eout de um modelo ajustado por fit em relação ao alvo gravado por generate nos metadados da base.
+//A primeira linha é E_{x,y}[(g - y)²], na escala de select e regularize; as demais são a integral
+//de (g - f)² em [-1;1], sem ruído, o dobro da parte determinística da primeira.
+func eout(args []string) error {
+	fs := flag.NewFlagSet("eout", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base com o alvo nos metadados")
+	arquivo := fs.String("model", "modelo.json", "arquivo do modelo ajustado")
+	conferencia := fs.Bool("check", false, "calcula também pela fórmula de monômios e por quadratura, para conferência")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+	if len(b.A) == 0 {
+		return fmt.Errorf("%s não tem o alvo nos metadados", *dados)
+	}
+	var m modelo
+	if err := leJSON(*arquivo, &m); err != nil {
+		return err
+	}
+
+	if m.Legendre != nil {
+		fmt.Printf("eout(g%d): %v\n", m.Grau, lfdoverfitting.EoutEsperado(b, m.Legendre))
+		fmt.Printf("integral de (g%d - f)² em [-1;1]: %v\n", m.Grau, lfdoverfitting.EoutLegendre(b.A, m.Legendre))
+	}
+	if m.Legendre == nil || *conferencia {
+		f, err := b.Monomial()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("integral de (g%d - f)² em [-1;1] por monômios: %v\n", m.Grau, lfdoverfitting.Eout(f, m.Coef))
+	}
+	if *conferencia {
+		//(g - f)² tem grau 2 max(Qf, grau), integrado exatamente com max(Qf, grau) + 1 nós
+		ordem := len(b.A)
+		if m.Grau+1 > ordem {
+			ordem = m.Grau + 1
+		}
+		e, err := lfdoverfitting.EoutFuncao(b.Avalia, m.Coef.Avalia, ordem)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("integral de (g%d - f)² em [-1;1] por quadratura: %v\n", m.Grau, e)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//fit ajusta um polinômio de grau n a uma base gravada por generate
+func fit(args []string) error {
+	fs := flag.NewFlagSet("fit", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base")
+	grau := fs.Int("degree", 2, "grau da hipótese")
+	lambda := fs.Float64("lambda", 0, "weight decay (0 = mínimos quadrados puro)")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	saida := fs.String("o", "modelo.json", "arquivo do modelo ajustado")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+	a, err := lfdoverfitting.AjustaRegularizado(b, *grau, lfdoverfitting.Regularizacao{Lambda: *lambda, Penalidade: *penalidade})
+	if err != nil {
+		return err
+	}
+
+	if a.Monomial != nil {
+		fmt.Printf("g%d: %v\n", *grau, a.Monomial)
+	}
+	fmt.Printf("legendre: %v\n", a.Legendre)
+	fmt.Printf("condição: %v, posto: %d de %d, resíduo: %v, parâmetros efetivos: %v\n", a.Condicao, a.Posto, *grau+1, a.Residuo, a.Efetivos)
+	m := modelo{
+		Grau:     *grau,
+		Coef:     a.Monomial,
+		Legendre: a.Legendre,
+		Condicao: a.Condicao,
+		Posto:    a.Posto,
+		Residuo:  a.Residuo,
+	}
+	if math.IsInf(m.Condicao, 0) {
+		m.Condicao = 0 //JSON não representa +Inf; o posto indica a matriz singular
+	}
+	return escreveJSON(*saida, m)
+}
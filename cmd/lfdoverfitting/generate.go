@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//generate sorteia um alvo de grau qf e uma base de n pontos com ruído gaussiano
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	qf := fs.Int("qf", 2, "grau da função alvo")
+	n := fs.Int("n", 20, "número de pontos da base")
+	sigma := fs.Float64("sigma", 0.0, "desvio padrão do ruído gaussiano")
+	seed := fs.Int64("seed", 0, "semente do gerador aleatório (0 = derivada do relógio)")
+	saida := fs.String("o", "base.csv", "arquivo da base, com o alvo e o ruído nos metadados")
+	colunaF := fs.Bool("f", false, "grava a coluna f com f(x) sem ruído")
+	colunaRuido := fs.Bool("noise", false, "grava a coluna ruido com y - f(x)")
+	monomial := fs.Bool("monomial", false, "grava nos metadados (# F:) o alvo na base de monômios")
+	fs.Parse(args)
+
+	rng := lfdoverfitting.NovoRNG(semente(*seed))
+	b := lfdoverfitting.GeraBase(rng, *qf, *n, lfdoverfitting.RuidoGaussiano{Sigma: *sigma})
+	return lfdoverfitting.EscreveBaseArquivo(*saida, b, lfdoverfitting.Colunas{F: *colunaF, Ruido: *colunaRuido, Monomial: *monomial})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+	"gonum.org/v1/plot/vg"
+)
+
+//heatmap desenha o mapa de calor do overfit a partir da tabela gravada por sweep ou run
+func heatmap(args []string) error {
+	fs := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	dados := fs.String("data", "sweep.csv", "tabela CSV gravada por sweep ou run")
+	eixos := fs.String("axes", "n-sigma", "eixos do mapa: n-sigma (Qf fixo) ou n-qf (sigma fixo)")
+	qf := fs.Int("qf", 20, "Qf fixo nos eixos n-sigma")
+	sigma := fs.Float64("sigma", 0.1, "sigma fixo nos eixos n-qf")
+	simples := fs.Int("simple", 0, "grau simples do par a desenhar, nas tabelas do run (0 = primeiro par)")
+	complexo := fs.Int("complex", 0, "grau complexo do par a desenhar, nas tabelas do run")
+	lambda := fs.Float64("lambda", 0, "weight decay da hipótese complexa do par a desenhar")
+	limite := fs.Float64("limit", 0, "a escala de cores vai de -limit a limit (0 = maior |overfit|)")
+	saida := fs.String("o", "overfit.png", "arquivo da figura; a extensão escolhe o formato (png, svg, pdf)")
+	titulo := fs.String("title", "", "título do gráfico")
+	largura := fs.Float64("width", 5, "largura em polegadas, incluindo a barra de cores")
+	altura := fs.Float64("height", 4, "altura em polegadas")
+	fs.Parse(args)
+
+	file, err := os.Open(*dados)
+	if err != nil {
+		return err
+	}
+	comparacoes, err := lfdoverfitting.LeComparacoes(file)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %v", *dados, err)
+	}
+
+	c := comparacoes[0]
+	if *simples != 0 || *complexo != 0 || *lambda != 0 {
+		par := lfdoverfitting.Par{Simples: *simples, Complexo: *complexo}
+		achou := false
+		for _, cmp := range comparacoes {
+			h := cmp.Hipoteses
+			if h.Simples == par.Simples && h.Complexo == par.Complexo && h.Lambda == *lambda {
+				c, achou = cmp, true
+			}
+		}
+		if !achou {
+			return fmt.Errorf("%s: não há resultados para o par %d/%d", *dados, par.Simples, par.Complexo)
+		}
+	}
+
+	m := lfdoverfitting.MapaOverfit{
+		Eixos:   lfdoverfitting.EixosMapa(*eixos),
+		Fixo:    float64(*qf),
+		Titulo:  *titulo,
+		Limite:  *limite,
+		Largura: vg.Length(*largura) * vg.Inch,
+		Altura:  vg.Length(*altura) * vg.Inch,
+	}
+	if m.Eixos == lfdoverfitting.EixosNQf {
+		m.Fixo = *sigma
+	}
+	if h := c.Hipoteses; m.Titulo == "" && h != (lfdoverfitting.Par{}) {
+		m.Titulo = fmt.Sprintf("Eout(g%d) - Eout(g%d)", h.Complexo, h.Simples)
+		if h.Lambda != 0 {
+			m.Titulo = fmt.Sprintf("Eout(g%d, %s) - Eout(g%d)", h.Complexo, h.Regularizacao, h.Simples)
+		}
+	}
+	return m.Desenha(*saida, c.Tabela)
+}
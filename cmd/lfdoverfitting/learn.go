@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+	"gonum.org/v1/plot/vg"
+)
+
+//learn traça a curva de aprendizado (E_in e E_out esperados em função de N) e opcionalmente a desenha
+func learn(args []string) error {
+	fs := flag.NewFlagSet("learn", flag.ExitOnError)
+	qf := fs.Int("qf", 10, "grau do alvo")
+	sigma := fs.Float64("sigma", 0.5, "desvio do ruído gaussiano; também traça a linha sigma² na figura (com -data, só se for passado)")
+	grau := fs.Int("degree", 2, "grau da hipótese")
+	ns := fs.String("n", "5,10,20,40,60,80,100,120", "tamanhos da base, separados por vírgula")
+	lambda := fs.Float64("lambda", 0, "weight decay da hipótese (0 = sem regularização)")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	bases := fs.Int("datasets", 1000, "bases sorteadas por N")
+	aninhadas := fs.Bool("nested", false, "bases de cada repetição como prefixos de uma mesma amostra")
+	seed := fs.Int64("seed", 0, "semente mãe dos geradores aleatórios (0 = derivada do relógio)")
+	dados := fs.String("data", "", "curva já calculada (CSV de learn -o); pula o experimento e só desenha")
+	saida := fs.String("o", "", "arquivo CSV da curva (vazio = saída padrão)")
+	figura := fs.String("plot", "", "arquivo da figura; a extensão escolhe o formato (png, svg, pdf)")
+	titulo := fs.String("title", "", "título do gráfico")
+	largura := fs.Float64("width", 4, "largura em polegadas")
+	altura := fs.Float64("height", 4, "altura em polegadas")
+	ymin := fs.Float64("ymin", 0, "limite inferior do eixo y (ymin = ymax escolhe automaticamente)")
+	ymax := fs.Float64("ymax", 0, "limite superior do eixo y")
+	fs.Parse(args)
+
+	var a lfdoverfitting.Aprendizado
+	linhaSigma := *sigma
+	if *dados != "" {
+		//o CSV não guarda o sigma da curva: o padrão de -sigma desenharia uma linha sem relação com os dados
+		linhaSigma = 0
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "sigma" {
+				linhaSigma = *sigma
+			}
+		})
+		f, err := os.Open(*dados)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if a, err = lfdoverfitting.LeAprendizado(f); err != nil {
+			return err
+		}
+	} else {
+		tamanhos, err := inteiros(*ns)
+		if err != nil {
+			return err
+		}
+		c := lfdoverfitting.CurvaAprendizado{
+			Qf:            *qf,
+			Ns:            tamanhos,
+			Sigma:         *sigma,
+			Grau:          *grau,
+			Regularizacao: lfdoverfitting.Regularizacao{Lambda: *lambda, Penalidade: *penalidade},
+			Bases:         *bases,
+			Aninhadas:     *aninhadas,
+			Semente:       semente(*seed),
+		}
+		if a, err = c.Executa(); err != nil {
+			return err
+		}
+		if *saida == "" && *figura == "" {
+			if err := a.EscreveCSV(os.Stdout); err != nil {
+				return err
+			}
+		} else if *saida != "" {
+			if err := cria(*saida, a.EscreveCSV); err != nil {
+				return err
+			}
+		}
+	}
+
+	if *figura == "" {
+		return nil
+	}
+	gr := lfdoverfitting.Grafico{
+		Titulo:  *titulo,
+		Largura: vg.Length(*largura) * vg.Inch,
+		Altura:  vg.Length(*altura) * vg.Inch,
+		YMin:    *ymin,
+		YMax:    *ymax,
+	}
+	return a.Desenha(*figura, gr, linhaSigma)
+}
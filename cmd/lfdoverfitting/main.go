@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+const uso = `uso: lfdoverfitting <comando> [flags]
+
+comandos:
+  generate    gera uma base sintética e grava em arquivo
+  fit         ajusta um polinômio de grau n a uma base
+  eout        avalia o erro fora da amostra de um modelo em relação ao alvo
+  sweep       executa o experimento de overfit numa grade (Qf, N, sigma)
+  regularize  relata Ein, Eout e parâmetros efetivos do weight decay ao longo de lambda
+  plot        desenha a base e os modelos ajustados
+  run         executa um experimento descrito num arquivo JSON
+  select      escolhe grau e lambda por validação e relata o Eout do escolhido
+  analyze     compara graus de hipótese numa base externa x,y sem alvo conhecido
+  biasvar     decompõe o Eout esperado de cada grau em viés, variância e ruído
+  learn       traça a curva de aprendizado de E_in e E_out em função de N
+  table       mostra o erro da matriz big.Float de legendre em relação aos coeficientes racionais exatos
+  noise       compara o ruído determinístico do alvo com o ruído estocástico
+  heatmap     desenha o mapa de calor do overfit a partir da tabela de sweep ou run
+
+use "lfdoverfitting <comando> -h" para as flags de cada comando
+`
+
+var comandos = map[string]func(args []string) error{
+	"generate":   generate,
+	"fit":        fit,
+	"eout":       eout,
+	"sweep":      sweep,
+	"plot":       plotCmd,
+	"run":        run,
+	"analyze":    analyze,
+	"select":     selectCmd,
+	"heatmap":    heatmap,
+	"noise":      noise,
+	"biasvar":    biasvar,
+	"learn":      learn,
+	"table":      table,
+	"regularize": regularize,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, uso)
+		os.Exit(2)
+	}
+	cmd, ok := comandos[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "comando desconhecido %q\n\n%s", os.Args[1], uso)
+		os.Exit(2)
+	}
+	checkError(cmd(os.Args[2:]))
+}
+
+func checkError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+//interrompivel devolve um contexto cancelado por SIGINT ou SIGTERM
+func interrompivel() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+//interrompido explica como retomar um sweep cancelado por sinal; outros erros passam adiante
+func interrompido(err error, checkpoint string) error {
+	if err != context.Canceled {
+		return err
+	}
+	if checkpoint == "" {
+		return errors.New("interrompido; use -checkpoint para poder retomar")
+	}
+	return fmt.Errorf("interrompido; células concluídas gravadas em %s, repita o comando para retomar", checkpoint)
+}
+
+//modelo hipótese ajustada gravada pelo comando fit
+type modelo struct {
+	Grau     int                 `json:"grau"`
+	Coef     lfdoverfitting.Poly `json:"coef"`               //indices do polinômio. Ex.: coef[0]x^0 + ... + coef[n]x^n
+	Legendre []float64           `json:"legendre,omitempty"` //coeficientes na base de legendre
+	Condicao float64             `json:"condicao,omitempty"` //número de condição da matriz de projeto
+	Posto    int                 `json:"posto,omitempty"`
+	Residuo  float64             `json:"residuo,omitempty"` //norma do resíduo nos pontos da base
+}
+
+//semente devolve s ou, se s = 0, uma semente derivada do relógio; a semente usada vai para stderr
+func semente(s int64) int64 {
+	if s == 0 {
+		s = time.Now().UnixNano()
+	}
+	fmt.Fprintf(os.Stderr, "semente: %d\n", s)
+	return s
+}
+
+func escreveJSON(path string, v interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+//cria grava o arquivo path com a função escreve
+func cria(path string, escreve func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := escreve(file); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func leJSON(path string, v interface{}) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return json.NewDecoder(file).Decode(v)
+}
+
+//inteiros lê uma lista separada por vírgulas, ex.: "20,40,60"
+func inteiros(s string) ([]int, error) {
+	var l []int
+	for _, c := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(c))
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, v)
+	}
+	return l, nil
+}
+
+//reais lê uma lista separada por vírgulas, ex.: "0,0.5,1"
+func reais(s string) ([]float64, error) {
+	var l []float64
+	for _, c := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(c), 64)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, v)
+	}
+	return l, nil
+}
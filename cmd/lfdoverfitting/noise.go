@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//noise compara o ruído determinístico do alvo da base, para cada grau de hipótese, com o ruído estocástico
+func noise(args []string) error {
+	fs := flag.NewFlagSet("noise", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base com o alvo nos metadados")
+	graus := fs.String("degrees", "2,10", "graus das hipóteses, separados por vírgula")
+	espectro := fs.Bool("spectrum", false, "imprime também a energia de cada grau de legendre do alvo")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+	if len(b.A) == 0 {
+		return fmt.Errorf("%s não tem o alvo nos metadados", *dados)
+	}
+	gs, err := inteiros(*graus)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("energia do alvo: %v\n", b.Energia())
+	if b.Ruido != nil {
+		fmt.Printf("ruído estocástico (%s): %v\n", b.Ruido.Nome(), b.Ruido.Energia())
+	}
+	for _, g := range gs {
+		rd, err := b.RuidoDeterministico(g)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("ruído determinístico (H_%d): %v\n", g, rd.Energia)
+	}
+	if *espectro {
+		for q, e := range b.Espectro() {
+			fmt.Printf("q=%d: %v\n", q, e)
+		}
+	}
+	return nil
+}
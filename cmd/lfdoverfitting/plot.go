@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/rgarrot/lfdoverfitting"
+	"gonum.org/v1/plot/vg"
+)
+
+//plotCmd desenha a base, o alvo dos metadados e as hipóteses ajustadas por fit
+func plotCmd(args []string) error {
+	fs := flag.NewFlagSet("plot", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base")
+	modelos := fs.String("models", "", "arquivos de modelos ajustados, separados por vírgula")
+	saida := fs.String("o", "points.png", "arquivo da figura; a extensão escolhe o formato (png, svg, pdf)")
+	titulo := fs.String("title", "", "título do gráfico")
+	largura := fs.Float64("width", 4, "largura em polegadas")
+	altura := fs.Float64("height", 4, "altura em polegadas")
+	ymin := fs.Float64("ymin", 0, "limite inferior do eixo y (ymin = ymax escolhe automaticamente)")
+	ymax := fs.Float64("ymax", 0, "limite superior do eixo y")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+
+	var curvas []lfdoverfitting.Curva
+	if *modelos != "" {
+		for _, arquivo := range strings.Split(*modelos, ",") {
+			var m modelo
+			if err := leJSON(arquivo, &m); err != nil {
+				return err
+			}
+			//os coeficientes de legendre são exatos em qualquer grau; coef fica para modelos gravados sem eles
+			nome := fmt.Sprintf("g%d", m.Grau)
+			if len(m.Legendre) > 0 {
+				curvas = append(curvas, lfdoverfitting.CurvaLegendre(nome, m.Legendre))
+			} else {
+				curvas = append(curvas, lfdoverfitting.CurvaPolinomio(nome, m.Coef))
+			}
+		}
+	}
+
+	gr := lfdoverfitting.Grafico{
+		Titulo:  *titulo,
+		Largura: vg.Length(*largura) * vg.Inch,
+		Altura:  vg.Length(*altura) * vg.Inch,
+		YMin:    *ymin,
+		YMax:    *ymax,
+	}
+	return gr.Desenha(*saida, b, curvas...)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//regularize ajusta uma base com weight decay ao longo de uma grade de lambdas e relata Ein, Eout e parâmetros efetivos
+func regularize(args []string) error {
+	fs := flag.NewFlagSet("regularize", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base; Eout exige o alvo nos metadados")
+	grau := fs.Int("degree", 10, "grau da hipótese")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	lambdas := fs.String("lambdas", "", "valores de lambda separados por vírgula; se vazio usa a grade logarítmica -from, -to, -steps")
+	de := fs.Float64("from", 1e-4, "menor lambda da grade logarítmica")
+	ate := fs.Float64("to", 10, "maior lambda da grade logarítmica")
+	passos := fs.Int("steps", 21, "número de lambdas da grade logarítmica")
+	saida := fs.String("o", "", "arquivo CSV do relatório (vazio = saída padrão)")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+
+	var grade []float64
+	if *lambdas != "" {
+		grade, err = reais(*lambdas)
+	} else {
+		grade, err = lfdoverfitting.Intervalo{Escala: "log", De: *de, Ate: *ate, Passos: *passos}.Expande()
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := lfdoverfitting.VarreLambda(b, *grau, *penalidade, grade)
+	if err != nil {
+		return err
+	}
+	if *saida == "" {
+		return c.EscreveCSV(os.Stdout)
+	}
+	return cria(*saida, c.EscreveCSV)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//run lê uma especificação de experimento, valida e executa, gravando as saídas nela descritas
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	validar := fs.Bool("check", false, "apenas valida a especificação")
+	checkpoint := fs.String("checkpoint", "", "arquivo onde as células concluídas são gravadas e de onde a execução é retomada")
+	trabalhadores := fs.Int("workers", 0, "número de goroutines do sweep (0 = valor da especificação)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "uso: lfdoverfitting run [flags] experimento.json")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	e, err := lfdoverfitting.LeEspecificacao(file)
+	file.Close()
+	if err != nil || *validar {
+		return err
+	}
+	for _, saida := range []string{e.Saida.CSV, e.Saida.JSON} {
+		if mesmoArquivo(saida, fs.Arg(0)) {
+			return fmt.Errorf("a saída %s sobrescreveria a própria especificação", saida)
+		}
+	}
+	if *trabalhadores > 0 {
+		e.Trabalhadores = *trabalhadores
+	}
+
+	ctx, pare := interrompivel()
+	defer pare()
+	var cp *lfdoverfitting.Checkpoint
+	if *checkpoint != "" {
+		if cp, err = lfdoverfitting.AbreCheckpoint(*checkpoint); err != nil {
+			return err
+		}
+		defer cp.Close()
+	}
+
+	var inicio = time.Now()
+	r, err := e.ExecutaContexto(ctx, cp)
+	if err != nil {
+		return interrompido(err, *checkpoint)
+	}
+	fmt.Fprintf(os.Stderr, "tempo total:  %s\n", time.Since(inicio))
+
+	if e.Saida.CSV == "" && e.Saida.JSON == "" {
+		return r.EscreveCSV(os.Stdout)
+	}
+	if e.Saida.CSV != "" {
+		if err := cria(e.Saida.CSV, r.EscreveCSV); err != nil {
+			return err
+		}
+	}
+	if e.Saida.JSON != "" {
+		if err := cria(e.Saida.JSON, r.EscreveJSON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//mesmoArquivo informa se o caminho a, quando existe, é o mesmo arquivo que b
+func mesmoArquivo(a string, b string) bool {
+	if a == "" {
+		return false
+	}
+	ia, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	ib, err := os.Stat(b)
+	return err == nil && os.SameFile(ia, ib)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//selectCmd escolhe grau (e opcionalmente lambda) por validação e compara o Eout do escolhido com o dos demais candidatos
+func selectCmd(args []string) error {
+	fs := flag.NewFlagSet("select", flag.ExitOnError)
+	dados := fs.String("data", "base.csv", "arquivo da base; Eout exige o alvo nos metadados")
+	graus := fs.String("degrees", "0,1,2,3,4,5,6,7,8,9,10", "graus candidatos, separados por vírgula")
+	lambdas := fs.String("lambdas", "0", "valores de lambda candidatos para cada grau, separados por vírgula")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	metodo := fs.String("method", "loo", "método de validação: loo, kfold ou holdout")
+	folds := fs.Int("folds", 10, "partes do kfold")
+	k := fs.Int("holdout", 0, "pontos no conjunto de validação do holdout (0 = N/5)")
+	seed := fs.Int64("seed", 0, "semente do sorteio das partições (0 = derivada do relógio)")
+	saida := fs.String("o", "", "arquivo CSV do relatório (vazio = saída padrão)")
+	fs.Parse(args)
+
+	b, err := lfdoverfitting.LeBaseArquivo(*dados)
+	if err != nil {
+		return err
+	}
+	gs, err := inteiros(*graus)
+	if err != nil {
+		return err
+	}
+	ls, err := reais(*lambdas)
+	if err != nil {
+		return err
+	}
+	var candidatos []lfdoverfitting.Candidato
+	for _, g := range gs {
+		for _, l := range ls {
+			c := lfdoverfitting.Candidato{Grau: g}
+			if l != 0 {
+				c.Regularizacao = lfdoverfitting.Regularizacao{Lambda: l, Penalidade: *penalidade}
+			}
+			candidatos = append(candidatos, c)
+		}
+	}
+	if *k == 0 {
+		*k = len(b.X) / 5
+	}
+
+	v := lfdoverfitting.Validacao{Metodo: *metodo, Folds: *folds, K: *k}
+	s, err := lfdoverfitting.SelecionaModelo(lfdoverfitting.NovoRNG(semente(*seed)), b, candidatos, v)
+	if err != nil {
+		return err
+	}
+	escolhido := s.Candidatos[s.Escolhido]
+	fmt.Fprintf(os.Stderr, "escolhido por %s: %s\n", *metodo, escolhido.Candidato)
+	if !math.IsNaN(s.Arrependimento) {
+		fmt.Fprintf(os.Stderr, "eout do escolhido: %v; melhor candidato %s com eout %v\n",
+			escolhido.Eout, s.Candidatos[s.Oraculo].Candidato, s.Candidatos[s.Oraculo].Eout)
+	}
+
+	if *saida == "" {
+		return s.EscreveCSV(os.Stdout)
+	}
+	return cria(*saida, s.EscreveCSV)
+}
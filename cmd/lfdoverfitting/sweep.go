@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rgarrot/lfdoverfitting"
+)
+
+//sweep executa o experimento de overfit na grade (Qf, N, sigma) e grava a tabela em CSV
+func sweep(args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	qfs := fs.String("qf", "5,10,15,20", "graus do alvo, separados por vírgula")
+	ns := fs.String("n", "20,40,60,80,100,120", "tamanhos da base, separados por vírgula")
+	sigmas := fs.String("sigma", "0,0.5,1", "desvios do ruído, separados por vírgula")
+	repeticoes := fs.Int("reps", 100, "repetições por célula")
+	simples := fs.Int("simple", 2, "grau da hipótese simples")
+	complexo := fs.Int("complex", 10, "grau da hipótese complexa")
+	lambda := fs.Float64("lambda", 0, "weight decay da hipótese complexa (0 = sem regularização)")
+	penalidade := fs.String("penalty", "ridge", "penalidade do weight decay: ridge ou legendre")
+	seed := fs.Int64("seed", 0, "semente mãe dos geradores aleatórios (0 = derivada do relógio)")
+	trabalhadores := fs.Int("workers", 0, "número de goroutines do sweep (0 = número de CPUs)")
+	saida := fs.String("o", "", "arquivo CSV da tabela (vazio = saída padrão)")
+	checkpoint := fs.String("checkpoint", "", "arquivo onde as células concluídas são gravadas e de onde a execução é retomada; exige -seed")
+	fs.Parse(args)
+	if *checkpoint != "" && *seed == 0 {
+		//a assinatura do checkpoint inclui a semente: uma derivada do relógio nunca casaria com a da execução anterior
+		return fmt.Errorf("-checkpoint exige -seed diferente de 0 para que a retomada use as mesmas sementes")
+	}
+
+	var g lfdoverfitting.Grade
+	var err error
+	if g.Qf, err = inteiros(*qfs); err != nil {
+		return err
+	}
+	if g.N, err = inteiros(*ns); err != nil {
+		return err
+	}
+	if g.Sigma, err = reais(*sigmas); err != nil {
+		return err
+	}
+
+	var inicio = time.Now()
+	s := lfdoverfitting.NovoSweep(g, *repeticoes, semente(*seed))
+	s.GrauSimples = *simples
+	s.GrauComplexo = *complexo
+	s.Regularizacao = lfdoverfitting.Regularizacao{Lambda: *lambda, Penalidade: *penalidade}
+	s.Trabalhadores = *trabalhadores
+
+	ctx, pare := interrompivel()
+	defer pare()
+	if *checkpoint != "" {
+		cp, err := lfdoverfitting.AbreCheckpoint(*checkpoint)
+		if err != nil {
+			return err
+		}
+		defer cp.Close()
+		cp.Retoma(&s)
+	}
+	t, err := s.ExecutaContexto(ctx)
+	if err != nil {
+		return interrompido(err, *checkpoint)
+	}
+	fmt.Fprintf(os.Stderr, "tempo total:  %s\n", time.Since(inicio))
+
+	if *saida == "" {
+		return t.EscreveCSV(os.Stdout)
+	}
+	return cria(*saida, t.EscreveCSV)
+}
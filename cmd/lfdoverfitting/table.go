@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/rgarrot/lfdoverfitting"
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+//table compara a matriz de CriaMatrizLegendre com a tabela racional exata e mostra o erro relativo por grau
+func table(args []string) error {
+	fs := flag.NewFlagSet("table", flag.ExitOnError)
+	grau := fs.Int("degree", 100, "grau máximo da matriz verificada")
+	fs.Parse(args)
+	if *grau < 0 {
+		return fmt.Errorf("-degree %d negativo", *grau)
+	}
+
+	m := lfdoverfitting.CriaMatrizLegendre(*grau)
+	erros, err := lfdoverfitting.ErroMatrizLegendre(m, legendre.NovaTabelaRacional())
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(os.Stdout)
+	if err := cw.Write([]string{"grau", "erro_relativo"}); err != nil {
+		return err
+	}
+	for k, e := range erros {
+		if err := cw.Write([]string{strconv.Itoa(k), strconv.FormatFloat(e, 'g', 3, 64)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,372 @@
+package lfdoverfitting
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//Especificacao descrição declarativa de um experimento de overfit, lida de um arquivo JSON.
+//Exemplo:
+//	{
+//	  "qf": [20],
+//	  "n": {"escala": "linear", "de": 20, "ate": 130, "passos": 12},
+//	  "sigma": {"escala": "linear", "de": 0, "ate": 2, "passos": 21},
+//	  "hipoteses": [{"simples": 2, "complexo": 10}],
+//	  "repeticoes": 1000,
+//	  "semente": 1,
+//	  "saida": {"csv": "figura4.3.csv"}
+//	}
+type Especificacao struct {
+	Nome          string           `json:"nome,omitempty"`
+	Qf            Intervalo        `json:"qf"`
+	N             Intervalo        `json:"n"`
+	Sigma         Intervalo        `json:"sigma"`
+	Hipoteses     []Par            `json:"hipoteses"`
+	Ruido         ModeloRuido      `json:"ruido"`
+	Repeticoes    int              `json:"repeticoes"`
+	Semente       int64            `json:"semente"`
+	Trabalhadores int              `json:"trabalhadores,omitempty"`
+	Saida         SaidaExperimento `json:"saida"`
+}
+
+//Intervalo valores de um parâmetro: lista explícita ou faixa linear/logarítmica de Passos valores entre De e Ate.
+//Em JSON aceita também uma lista simples, ex.: [20, 40, 60].
+type Intervalo struct {
+	Valores []float64 `json:"valores,omitempty"`
+	Escala  string    `json:"escala,omitempty"` //"linear" ou "log"
+	De      float64   `json:"de,omitempty"`
+	Ate     float64   `json:"ate,omitempty"`
+	Passos  int       `json:"passos,omitempty"`
+}
+
+//Par de graus de hipóteses comparadas: overfit = Eout(gComplexo) - Eout(gSimples).
+//A regularização opcional vale para a hipótese complexa, ex.: {"simples": 2, "complexo": 10, "lambda": 0.01}.
+type Par struct {
+	Simples  int `json:"simples"`
+	Complexo int `json:"complexo"`
+	Regularizacao
+}
+
+//ModeloRuido nome e parâmetros extras do modelo de ruído (ver FabricaRuido)
+type ModeloRuido struct {
+	Modelo     string             `json:"modelo,omitempty"`
+	Parametros map[string]float64 `json:"parametros,omitempty"`
+}
+
+//SaidaExperimento arquivos gerados pelo experimento; vazios são ignorados
+type SaidaExperimento struct {
+	CSV  string `json:"csv,omitempty"`
+	JSON string `json:"json,omitempty"`
+}
+
+//Comparacao tabela do sweep para um par de hipóteses
+type Comparacao struct {
+	Hipoteses Par    `json:"hipoteses"`
+	Tabela    Tabela `json:"tabela"`
+}
+
+//Relatorio resultado de um experimento, com a especificação embutida
+type Relatorio struct {
+	Especificacao Especificacao `json:"especificacao"`
+	Comparacoes   []Comparacao  `json:"comparacoes"`
+}
+
+//LeEspecificacao lê e valida uma especificação JSON; campos desconhecidos são erro
+func LeEspecificacao(r io.Reader) (Especificacao, error) {
+	var e Especificacao
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&e); err != nil {
+		return e, fmt.Errorf("especificação: %v", err)
+	}
+	return e, e.Valida()
+}
+
+//UnmarshalJSON aceita tanto o objeto Intervalo quanto uma lista de valores
+func (iv *Intervalo) UnmarshalJSON(dados []byte) error {
+	if b := bytes.TrimSpace(dados); len(b) > 0 && b[0] == '[' {
+		*iv = Intervalo{}
+		return json.Unmarshal(b, &iv.Valores)
+	}
+	type intervalo Intervalo //sem o método, evita recursão
+	return json.Unmarshal(dados, (*intervalo)(iv))
+}
+
+//Expande devolve os valores do intervalo em ordem
+func (iv Intervalo) Expande() ([]float64, error) {
+	if len(iv.Valores) > 0 {
+		if iv.Escala != "" || iv.Passos != 0 {
+			return nil, errors.New("use valores ou escala/de/ate/passos, não ambos")
+		}
+		return iv.Valores, nil
+	}
+	if iv.Passos < 1 {
+		return nil, fmt.Errorf("passos = %d deve ser pelo menos 1", iv.Passos)
+	}
+	if iv.Passos == 1 {
+		return []float64{iv.De}, nil
+	}
+
+	vs := make([]float64, iv.Passos)
+	t := 1.0 / float64(iv.Passos-1)
+	switch iv.Escala {
+	case "", "linear":
+		for k := range vs {
+			vs[k] = iv.De + (iv.Ate-iv.De)*float64(k)*t
+		}
+	case "log":
+		if iv.De <= 0 || iv.Ate <= 0 {
+			return nil, fmt.Errorf("escala log exige de e ate positivos, não %v e %v", iv.De, iv.Ate)
+		}
+		for k := range vs {
+			vs[k] = iv.De * math.Pow(iv.Ate/iv.De, float64(k)*t)
+		}
+	default:
+		return nil, fmt.Errorf("escala desconhecida %q", iv.Escala)
+	}
+	vs[len(vs)-1] = iv.Ate
+	return vs, nil
+}
+
+//inteiros expande o intervalo arredondando para inteiros e descartando repetições (comuns na escala log)
+func (iv Intervalo) inteiros() ([]int, error) {
+	vs, err := iv.Expande()
+	if err != nil {
+		return nil, err
+	}
+	var is []int
+	for _, v := range vs {
+		i := int(math.Round(v))
+		if len(iv.Valores) > 0 && float64(i) != v {
+			return nil, fmt.Errorf("valor %v não é inteiro", v)
+		}
+		if len(is) == 0 || is[len(is)-1] != i {
+			is = append(is, i)
+		}
+	}
+	return is, nil
+}
+
+//Grade expande os intervalos da especificação
+func (e Especificacao) Grade() (Grade, error) {
+	var g Grade
+	var err error
+	if g.Qf, err = e.Qf.inteiros(); err != nil {
+		return g, fmt.Errorf("qf: %v", err)
+	}
+	if g.N, err = e.N.inteiros(); err != nil {
+		return g, fmt.Errorf("n: %v", err)
+	}
+	if g.Sigma, err = e.Sigma.Expande(); err != nil {
+		return g, fmt.Errorf("sigma: %v", err)
+	}
+	return g, nil
+}
+
+//Valida confere a especificação e devolve todos os problemas encontrados num único erro
+func (e Especificacao) Valida() error {
+	var problemas []string
+	g, err := e.Grade()
+	if err != nil {
+		problemas = append(problemas, err.Error())
+	}
+	for _, qf := range g.Qf {
+		if qf < 0 {
+			problemas = append(problemas, fmt.Sprintf("qf = %d deve ser não negativo", qf))
+		}
+	}
+	for _, sigma := range g.Sigma {
+		if sigma < 0 {
+			problemas = append(problemas, fmt.Sprintf("sigma = %v deve ser não negativo", sigma))
+		}
+	}
+
+	if len(e.Hipoteses) == 0 {
+		problemas = append(problemas, "hipoteses: ao menos um par de graus é necessário")
+	}
+	for _, h := range e.Hipoteses {
+		if h.Simples < 0 || h.Complexo < 0 {
+			problemas = append(problemas, fmt.Sprintf("hipoteses: graus %d e %d devem ser não negativos", h.Simples, h.Complexo))
+		}
+		if _, err := h.pesos(h.Complexo); err != nil && h.Complexo >= 0 {
+			problemas = append(problemas, "hipoteses: "+err.Error())
+		}
+		//com N <= grau o sistema de mínimos quadrados é indeterminado; o weight decay o determina
+		for _, n := range g.N {
+			if (n <= h.Complexo && h.Lambda == 0) || n <= h.Simples {
+				problemas = append(problemas, fmt.Sprintf("n = %d insuficiente para ajustar graus %d e %d", n, h.Simples, h.Complexo))
+				break
+			}
+		}
+	}
+
+	if e.Repeticoes < 2 {
+		problemas = append(problemas, fmt.Sprintf("repeticoes = %d deve ser pelo menos 2 para o erro padrão", e.Repeticoes))
+	}
+	if e.Trabalhadores < 0 {
+		problemas = append(problemas, fmt.Sprintf("trabalhadores = %d deve ser não negativo", e.Trabalhadores))
+	}
+	if _, err := FabricaRuido(e.Ruido.Modelo, e.Ruido.Parametros); err != nil {
+		problemas = append(problemas, err.Error())
+	}
+
+	if len(problemas) > 0 {
+		return errors.New("especificação inválida:\n\t" + strings.Join(problemas, "\n\t"))
+	}
+	return nil
+}
+
+//Executa valida a especificação e roda um sweep por par de hipóteses.
+//Todos os pares usam a mesma semente, portanto são comparados sobre as mesmas bases.
+func (e Especificacao) Executa() (Relatorio, error) {
+	return e.ExecutaContexto(context.Background(), nil)
+}
+
+//ExecutaContexto como Executa, interrompível por ctx e retomável pelo checkpoint cp (opcional)
+func (e Especificacao) ExecutaContexto(ctx context.Context, cp *Checkpoint) (Relatorio, error) {
+	r := Relatorio{Especificacao: e}
+	if err := e.Valida(); err != nil {
+		return r, err
+	}
+	g, _ := e.Grade()
+	ruido, _ := FabricaRuido(e.Ruido.Modelo, e.Ruido.Parametros)
+
+	for _, h := range e.Hipoteses {
+		s := NovoSweep(g, e.Repeticoes, e.Semente)
+		s.GrauSimples = h.Simples
+		s.GrauComplexo = h.Complexo
+		s.Regularizacao = h.Regularizacao
+		s.NovoRuido = ruido
+		s.Trabalhadores = e.Trabalhadores
+		if cp != nil {
+			cp.Retoma(&s)
+		}
+		t, err := s.ExecutaContexto(ctx)
+		if err != nil {
+			return r, err
+		}
+		r.Comparacoes = append(r.Comparacoes, Comparacao{Hipoteses: h, Tabela: t})
+	}
+	return r, nil
+}
+
+//EscreveJSON grava o relatório completo, com a especificação, em JSON
+func (r Relatorio) EscreveJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+//EscreveCSV grava as tabelas de todos os pares em CSV.
+//A especificação vai na primeira linha como comentário "# especificacao: {...}".
+func (r Relatorio) EscreveCSV(w io.Writer) error {
+	spec, err := json.Marshal(r.Especificacao)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "# especificacao: %s\n", spec); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"simples", "complexo", "lambda", "penalidade"}, cabecalhoCSV...)); err != nil {
+		return err
+	}
+	for _, c := range r.Comparacoes {
+		h := c.Hipoteses
+		par := []string{strconv.Itoa(h.Simples), strconv.Itoa(h.Complexo), strconv.FormatFloat(h.Lambda, 'g', -1, 64), h.Penalidade}
+		for _, res := range c.Tabela {
+			if err := cw.Write(append(par, linhaCSV(res)...)); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//LeComparacoes lê uma tabela gravada por Tabela.EscreveCSV ou Relatorio.EscreveCSV, uma comparação por par de hipóteses.
+//Tabelas do comando sweep não têm as colunas simples e complexo; nesse caso há uma única comparação com Hipoteses zerado.
+func LeComparacoes(r io.Reader) ([]Comparacao, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cabecalho, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("tabela: cabeçalho: %v", err)
+	}
+	col := map[string]int{}
+	for i, nome := range cabecalho {
+		col[strings.TrimSpace(nome)] = i
+	}
+	for _, nome := range cabecalhoCSV {
+		if _, ok := col[nome]; !ok {
+			return nil, fmt.Errorf("tabela: falta a coluna %q", nome)
+		}
+	}
+	_, temPar := col["simples"]
+
+	//campo coluna lida para dst (*int, *float64 ou *string)
+	type campo struct {
+		nome string
+		dst  interface{}
+	}
+
+	var comparacoes []Comparacao
+	indice := map[Par]int{}
+	for n := 2; ; n++ {
+		linha, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var p Par
+		var res Resultado
+		campos := []campo{
+			{"qf", &res.Qf}, {"n", &res.N}, {"sigma", &res.Sigma},
+			{"media", &res.Media}, {"erro_padrao", &res.ErroPadrao}, {"execucoes", &res.Execucoes},
+		}
+		if temPar {
+			campos = append(campos, campo{"simples", &p.Simples}, campo{"complexo", &p.Complexo})
+		}
+		if _, ok := col["lambda"]; ok {
+			campos = append(campos, campo{"lambda", &p.Lambda}, campo{"penalidade", &p.Penalidade})
+		}
+		for _, c := range campos {
+			v := strings.TrimSpace(linha[col[c.nome]])
+			switch d := c.dst.(type) {
+			case *int:
+				*d, err = strconv.Atoi(v)
+			case *float64:
+				*d, err = strconv.ParseFloat(v, 64)
+			case *string:
+				*d = v
+			}
+			if err != nil {
+				return nil, fmt.Errorf("tabela: linha %d, coluna %s: %v", n, c.nome, err)
+			}
+		}
+
+		i, ok := indice[p]
+		if !ok {
+			i = len(comparacoes)
+			indice[p] = i
+			comparacoes = append(comparacoes, Comparacao{Hipoteses: p})
+		}
+		comparacoes[i].Tabela = append(comparacoes[i].Tabela, res)
+	}
+	if len(comparacoes) == 0 {
+		return nil, errors.New("tabela: nenhum resultado")
+	}
+	return comparacoes, nil
+}
@@ -0,0 +1,193 @@
+package lfdoverfitting
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const especificacaoPequena = `{
+	"nome": "teste",
+	"qf": [3],
+	"n": {"escala": "linear", "de": 20, "ate": 30, "passos": 2},
+	"sigma": [0, 0.5],
+	"hipoteses": [{"simples": 2, "complexo": 5}, {"simples": 2, "complexo": 5, "lambda": 0.1, "penalidade": "legendre"}],
+	"ruido": {"modelo": "student-t", "parametros": {"nu": 5}},
+	"repeticoes": 3,
+	"semente": 7
+}`
+
+func TestLeEspecificacao(t *testing.T) {
+	e, err := LeEspecificacao(strings.NewReader(especificacaoPequena))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := e.Grade()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Grade{Qf: []int{3}, N: []int{20, 30}, Sigma: []float64{0, 0.5}}
+	if !reflect.DeepEqual(g, want) {
+		t.Errorf("grade: got %+v; want %+v", g, want)
+	}
+	if h := e.Hipoteses[1]; h.Lambda != 0.1 || h.Penalidade != PenalidadeLegendre {
+		t.Errorf("hipótese regularizada: got %+v", h)
+	}
+
+	if _, err := LeEspecificacao(strings.NewReader(`{"qf": [3], "graus": [2]}`)); err == nil {
+		t.Error("campo desconhecido aceito")
+	}
+}
+
+func TestIntervalo(t *testing.T) {
+	casos := []struct {
+		json string
+		want []float64
+	}{
+		{`[20, 40, 60]`, []float64{20, 40, 60}},
+		{`{"valores": [1, 2]}`, []float64{1, 2}},
+		{`{"de": 0, "ate": 1, "passos": 5}`, []float64{0, 0.25, 0.5, 0.75, 1}},
+		{`{"escala": "log", "de": 0.01, "ate": 1, "passos": 3}`, []float64{0.01, 0.1, 1}},
+		{`{"de": 4, "ate": 9, "passos": 1}`, []float64{4}},
+	}
+	for _, c := range casos {
+		var iv Intervalo
+		if err := json.Unmarshal([]byte(c.json), &iv); err != nil {
+			t.Fatalf("%s: %v", c.json, err)
+		}
+		got, err := iv.Expande()
+		if err != nil {
+			t.Fatalf("%s: %v", c.json, err)
+		}
+		if len(got) != len(c.want) {
+			t.Fatalf("%s: got %v; want %v", c.json, got, c.want)
+		}
+		for i := range got {
+			if math.Abs(got[i]-c.want[i]) > 1e-12*math.Abs(c.want[i]) {
+				t.Errorf("%s: got %v; want %v", c.json, got, c.want)
+				break
+			}
+		}
+	}
+
+	invalidos := []Intervalo{
+		{Valores: []float64{1}, Passos: 3},
+		{De: 1, Ate: 2},
+		{Escala: "log", De: 0, Ate: 1, Passos: 3},
+		{Escala: "quadratica", De: 1, Ate: 2, Passos: 3},
+	}
+	for _, iv := range invalidos {
+		if _, err := iv.Expande(); err == nil {
+			t.Errorf("%+v aceito", iv)
+		}
+	}
+}
+
+func TestIntervaloInteiros(t *testing.T) {
+	//na escala log os primeiros valores arredondados se repetem: 1, 1.26, 1.58, 2, ... viram 1, 2, ...
+	iv := Intervalo{Escala: "log", De: 1, Ate: 100, Passos: 21}
+	is, err := iv.inteiros()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if is[0] != 1 || is[len(is)-1] != 100 || len(is) >= 21 {
+		t.Errorf("got %v; want de 1 a 100 sem repetições", is)
+	}
+	for k := 1; k < len(is); k++ {
+		if is[k] <= is[k-1] {
+			t.Errorf("got %v; want estritamente crescente", is)
+			break
+		}
+	}
+
+	if _, err := (Intervalo{Valores: []float64{20, 30.5}}).inteiros(); err == nil {
+		t.Error("valor explícito não inteiro aceito")
+	}
+}
+
+func TestValida(t *testing.T) {
+	e, err := LeEspecificacao(strings.NewReader(especificacaoPequena))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Qf = Intervalo{Valores: []float64{-1}}
+	e.Sigma = Intervalo{Valores: []float64{-0.5}}
+	e.Repeticoes = 1
+	e.Ruido = ModeloRuido{Modelo: "cauchy"}
+	err = e.Valida()
+	if err == nil {
+		t.Fatal("especificação inválida aceita")
+	}
+	//todos os problemas num único erro
+	for _, trecho := range []string{"qf = -1", "sigma = -0.5", "repeticoes = 1", "cauchy"} {
+		if !strings.Contains(err.Error(), trecho) {
+			t.Errorf("erro %q não menciona %q", err, trecho)
+		}
+	}
+
+	//com N <= grau só o weight decay determina o ajuste
+	e, _ = LeEspecificacao(strings.NewReader(especificacaoPequena))
+	e.N = Intervalo{Valores: []float64{5}}
+	e.Hipoteses = []Par{{Simples: 2, Complexo: 5}}
+	if err := e.Valida(); err == nil {
+		t.Error("n = 5 aceito para o grau 5 sem regularização")
+	}
+}
+
+func TestValidaGrauNegativo(t *testing.T) {
+	_, err := LeEspecificacao(strings.NewReader(`{"qf": [5], "n": [20], "sigma": [0.5],
+		"hipoteses": [{"simples": 2, "complexo": -3}], "repeticoes": 10}`))
+	if err == nil || !strings.Contains(err.Error(), "-3") {
+		t.Errorf("got %v; want erro de validação do grau -3", err)
+	}
+}
+
+func TestRelatorio(t *testing.T) {
+	e, err := LeEspecificacao(strings.NewReader(especificacaoPequena))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := e.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Comparacoes) != 2 || len(r.Comparacoes[0].Tabela) != 4 {
+		t.Fatalf("got %d comparações; want 2 com 4 células", len(r.Comparacoes))
+	}
+
+	//o CSV traz a especificação na primeira linha e volta pelas mesmas tabelas
+	var csv bytes.Buffer
+	if err := r.EscreveCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	primeira := strings.SplitN(csv.String(), "\n", 2)[0]
+	var embutida Especificacao
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(primeira, "# especificacao: ")), &embutida); err != nil {
+		t.Fatalf("%q: %v", primeira, err)
+	}
+	if !reflect.DeepEqual(embutida, e) {
+		t.Errorf("especificação do CSV: got %+v; want %+v", embutida, e)
+	}
+	cs, err := LeComparacoes(&csv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cs, r.Comparacoes) {
+		t.Errorf("LeComparacoes: got %+v; want %+v", cs, r.Comparacoes)
+	}
+
+	var js bytes.Buffer
+	if err := r.EscreveJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	var lido Relatorio
+	if err := json.Unmarshal(js.Bytes(), &lido); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(lido, r) {
+		t.Errorf("JSON: got %+v; want %+v", lido, r)
+	}
+}
@@ -0,0 +1,187 @@
+package lfdoverfitting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+//Escala transformação afim que leva [Min;Max] em [-1;1]
+type Escala struct {
+	Min float64
+	Max float64
+}
+
+//Aplica leva x da escala original para [-1;1]
+func (e Escala) Aplica(x float64) float64 {
+	return 2*(x-e.Min)/(e.Max-e.Min) - 1
+}
+
+//Inverte leva x de [-1;1] de volta à escala original
+func (e Escala) Inverte(x float64) float64 {
+	return e.Min + (x+1)*(e.Max-e.Min)/2
+}
+
+//Reescala devolve uma cópia da base com x levado para [-1;1], onde os polinômios de legendre são ortogonais
+func Reescala(b Base) (Base, Escala, error) {
+	if len(b.X) == 0 {
+		return b, Escala{}, errors.New("reescala: base vazia")
+	}
+	e := Escala{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, x := range b.X {
+		e.Min = math.Min(e.Min, x)
+		e.Max = math.Max(e.Max, x)
+	}
+	if e.Min == e.Max {
+		return b, e, fmt.Errorf("reescala: todos os x valem %v", e.Min)
+	}
+
+	r := Base{X: make([]float64, len(b.X)), Y: append([]float64{}, b.Y...)}
+	for i, x := range b.X {
+		r.X[i] = e.Aplica(x)
+	}
+	return r, e, nil
+}
+
+//ImportaCSV lê uma base externa de regressão unidimensional.
+//Se a primeira linha não for numérica é tomada como cabeçalho e colX, colY escolhem as colunas pelo nome;
+//sem cabeçalho, ou com colX e colY vazios, são usadas as duas primeiras colunas. Linhas com '#' são comentários.
+func ImportaCSV(r io.Reader, colX string, colY string) (Base, error) {
+	var b Base
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	ix, iy := 0, 1
+	for n := 1; ; n++ {
+		linha, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return b, err
+		}
+
+		if n == 1 {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(linha[0]), 64); err != nil {
+				if ix, iy, err = colunas(linha, colX, colY); err != nil {
+					return b, err
+				}
+				continue
+			}
+		}
+
+		if len(linha) <= ix || len(linha) <= iy {
+			return b, fmt.Errorf("linha %d: faltam colunas", n)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(linha[ix]), 64)
+		if err != nil {
+			return b, fmt.Errorf("linha %d: %v", n, err)
+		}
+		y, err := strconv.ParseFloat(strings.TrimSpace(linha[iy]), 64)
+		if err != nil {
+			return b, fmt.Errorf("linha %d: %v", n, err)
+		}
+		b.X = append(b.X, x)
+		b.Y = append(b.Y, y)
+	}
+	if len(b.X) == 0 {
+		return b, errors.New("nenhum ponto lido")
+	}
+	return b, nil
+}
+
+//colunas índices das colunas de nomes colX e colY no cabeçalho
+func colunas(cabecalho []string, colX string, colY string) (int, int, error) {
+	if colX == "" && colY == "" {
+		return 0, 1, nil
+	}
+	ix, iy := -1, -1
+	for i, nome := range cabecalho {
+		switch strings.TrimSpace(nome) {
+		case colX:
+			ix = i
+		case colY:
+			iy = i
+		}
+	}
+	if ix < 0 || iy < 0 {
+		return 0, 0, fmt.Errorf("colunas %q e %q não encontradas em %v", colX, colY, cabecalho)
+	}
+	return ix, iy, nil
+}
+
+//AnaliseGrau erros empíricos de uma hipótese de grau Grau
+type AnaliseGrau struct {
+	Grau int
+	Ein  float64 //erro dentro da amostra
+	Eval float64 //erro no conjunto de holdout
+	Ecv  float64 //erro de validação cruzada
+}
+
+//AnaliseGraus comparação de graus numa base sem alvo conhecido.
+//Sem o alvo não há Eout analítico; Eval e Ecv são as estimativas empíricas do erro fora da amostra.
+type AnaliseGraus struct {
+	Graus       []AnaliseGrau
+	Selecionado int //grau com menor Ecv; -1 se nenhum Ecv é um número (todos NaN)
+	K           int //pontos no holdout
+	Folds       int //partes da validação cruzada
+}
+
+//AnalisaGraus ajusta cada grau e estima Ein, Eval (holdout de k pontos) e Ecv (folds partes).
+//Todos os graus usam a mesma partição sorteada por rng, o que torna as estimativas comparáveis entre si.
+func AnalisaGraus(rng *rand.Rand, b Base, graus []int, k int, folds int) (AnaliseGraus, error) {
+	a := AnaliseGraus{K: k, Folds: folds}
+	permHoldout := rng.Perm(len(b.X))
+	permCV := rng.Perm(len(b.X))
+
+	melhor := math.Inf(1)
+	a.Selecionado = -1
+	for _, grau := range graus {
+		g, err := AjustaLegendre(b, grau)
+		if err != nil {
+			return a, fmt.Errorf("grau %d: %v", grau, err)
+		}
+		ag := AnaliseGrau{Grau: grau, Ein: g.Residuo * g.Residuo / float64(len(b.X))}
+		if ag.Eval, err = holdout(b, Candidato{Grau: grau}, permHoldout, k); err != nil {
+			return a, fmt.Errorf("grau %d: %v", grau, err)
+		}
+		if ag.Ecv, err = validacaoCruzada(b, Candidato{Grau: grau}, permCV, folds); err != nil {
+			return a, fmt.Errorf("grau %d: %v", grau, err)
+		}
+		if !math.IsNaN(ag.Ecv) && (ag.Ecv < melhor || a.Selecionado < 0) {
+			melhor = ag.Ecv
+			a.Selecionado = grau
+		}
+		a.Graus = append(a.Graus, ag)
+	}
+	return a, nil
+}
+
+//EscreveCSV grava uma linha por grau com as colunas grau,ein,eval,ecv,selecionado
+func (a AnaliseGraus) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"grau", "ein", "eval", "ecv", "selecionado"}); err != nil {
+		return err
+	}
+	for _, g := range a.Graus {
+		linha := []string{
+			strconv.Itoa(g.Grau),
+			strconv.FormatFloat(g.Ein, 'g', -1, 64),
+			strconv.FormatFloat(g.Eval, 'g', -1, 64),
+			strconv.FormatFloat(g.Ecv, 'g', -1, 64),
+			strconv.FormatBool(g.Grau == a.Selecionado),
+		}
+		if err := cw.Write(linha); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
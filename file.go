@@ -1,60 +1,240 @@
-package main
+package lfdoverfitting
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 )
 
-var path = "C:\\Users\\Renan\\Desktop\\base.csv"
+//Colunas opcionais gravadas por EscreveBase além de x e y
+type Colunas struct {
+	F        bool //f: alvo sem ruído f(x)
+	Ruido    bool //ruido: y - f(x)
+	Monomial bool //metadado "# F:" com o alvo na base de monômios, só para exportação; LeBase usa apenas A
+}
+
+//EscreveBase grava a base em CSV com um bloco de metadados e uma linha de cabeçalho:
+//
+//	# A: a_0,a_1,...,a_qf
+//	# F: f_0,f_1,...,f_qf        (opcional, com Colunas.Monomial)
+//	# ruido: gaussiano sigma=0.3
+//	x,y,f,ruido
+//	-0.25,0.71,0.64,0.07
+//
+//Os reais são gravados na menor representação que volta ao mesmo valor,
+//de modo que LeBase devolve exatamente os mesmos X, Y, A e modelo de ruído.
+//As colunas f, ruido e F exigem o alvo; uma base sem alvo (importada por ImportaCSV, por exemplo) falha.
+func EscreveBase(w io.Writer, b Base, c Colunas) error {
+	if (c.F || c.Ruido || c.Monomial) && len(b.A) == 0 {
+		return errors.New("base sem alvo: as colunas f, ruido e F não podem ser gravadas")
+	}
+	bw := bufio.NewWriter(w)
+
+	if len(b.A) > 0 {
+		fmt.Fprintf(bw, "# A: %s\n", formataReais(b.A))
+	}
+	if c.Monomial {
+		f, err := b.Monomial()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "# F: %s\n", formataReais(f))
+	}
+	if b.Ruido != nil {
+		fmt.Fprintf(bw, "# ruido: %s\n", formataRuido(b.Ruido))
+	}
+
+	cabecalho := []string{"x", "y"}
+	if c.F {
+		cabecalho = append(cabecalho, "f")
+	}
+	if c.Ruido {
+		cabecalho = append(cabecalho, "ruido")
+	}
+	bw.WriteString(strings.Join(cabecalho, ",") + "\n")
+
+	for i := range b.X {
+		linha := []string{formataReal(b.X[i]), formataReal(b.Y[i])}
+		f := b.Avalia(b.X[i])
+		if c.F {
+			linha = append(linha, formataReal(f))
+		}
+		if c.Ruido {
+			linha = append(linha, formataReal(b.Y[i]-f))
+		}
+		bw.WriteString(strings.Join(linha, ",") + "\n")
+	}
+	return bw.Flush()
+}
+
+//LeBase lê uma base gravada por EscreveBase. Colunas além de x e y são ignoradas;
+//o alvo e o modelo de ruído vêm do bloco de metadados, quando presentes.
+func LeBase(r io.Reader) (Base, error) {
+	var b Base
+	scanner := bufio.NewScanner(r)
+	ix, iy := -1, -1
+	n := 0
+	for scanner.Scan() {
+		n++
+		linha := strings.TrimSpace(scanner.Text())
+		if linha == "" {
+			continue
+		}
+
+		if strings.HasPrefix(linha, "#") {
+			if err := leMetadado(&b, strings.TrimSpace(linha[1:])); err != nil {
+				return b, fmt.Errorf("linha %d: %v", n, err)
+			}
+			continue
+		}
+
+		campos := strings.Split(linha, ",")
+		if ix < 0 {
+			for i, nome := range campos {
+				switch strings.TrimSpace(nome) {
+				case "x":
+					ix = i
+				case "y":
+					iy = i
+				}
+			}
+			if ix < 0 || iy < 0 {
+				return b, fmt.Errorf("linha %d: o cabeçalho deve nomear as colunas x e y", n)
+			}
+			continue
+		}
+
+		if len(campos) <= ix || len(campos) <= iy {
+			return b, fmt.Errorf("linha %d: faltam as colunas x ou y", n)
+		}
+		x, err := strconv.ParseFloat(strings.TrimSpace(campos[ix]), 64)
+		if err != nil {
+			return b, fmt.Errorf("linha %d: %v", n, err)
+		}
+		y, err := strconv.ParseFloat(strings.TrimSpace(campos[iy]), 64)
+		if err != nil {
+			return b, fmt.Errorf("linha %d: %v", n, err)
+		}
+		b.X = append(b.X, x)
+		b.Y = append(b.Y, y)
+	}
+	if err := scanner.Err(); err != nil {
+		return b, err
+	}
+	if ix < 0 {
+		return b, errors.New("falta a linha de cabeçalho x,y")
+	}
+	return b, nil
+}
+
+//EscreveBaseArquivo cria (ou trunca) path e grava a base nele
+func EscreveBaseArquivo(path string, b Base, c Colunas) error {
+	arquivo, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer arquivo.Close()
+	if err := EscreveBase(arquivo, b, c); err != nil {
+		return err
+	}
+	//salva as alterações
+	return arquivo.Sync()
+}
+
+//LeBaseArquivo lê uma base gravada por EscreveBaseArquivo
+func LeBaseArquivo(path string) (Base, error) {
+	arquivo, err := os.Open(path)
+	if err != nil {
+		return Base{}, err
+	}
+	defer arquivo.Close()
+	b, err := LeBase(arquivo)
+	if err != nil {
+		return b, fmt.Errorf("%s: %v", path, err)
+	}
+	return b, nil
+}
 
-func writeBase(b Base) {
-	createFile()
-	writeFile(b)
+//leMetadado interpreta uma linha "# chave: valor"; comentários sem chave são ignorados
+func leMetadado(b *Base, linha string) error {
+	kv := strings.SplitN(linha, ":", 2)
+	if len(kv) != 2 {
+		return nil //comentário simples
+	}
+	valor := strings.TrimSpace(kv[1])
+	var err error
+	switch strings.TrimSpace(kv[0]) {
+	case "A":
+		b.A, err = leReais(valor)
+	case "ruido":
+		b.Ruido, err = leRuido(valor)
+	}
+	return err
 }
 
-func createFile() {
-	// detect if file exists
-	var _, err = os.Stat(path)
+func formataReal(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
 
-	// create file if not exists
-	if os.IsNotExist(err) {
-		var file, err = os.Create(path)
-		checkError(err)
-		defer file.Close()
+func formataReais(vs []float64) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = formataReal(v)
 	}
+	return strings.Join(s, ",")
 }
 
-func writeFile(b Base) {
-	// open file using READ & WRITE permission
-	var file, err = os.OpenFile(path, os.O_RDWR, 0644)
-	checkError(err)
-	defer file.Close()
-	for i := 0; i < len(b.Y); i++ {
-		_, err = file.WriteString(strconv.FormatFloat(b.X[i], 'f', -1, 64))
-		checkError(err)
-		_, err = file.WriteString(",")
-		checkError(err)
-		_, err = file.WriteString(strconv.FormatFloat(b.Y[i], 'f', -1, 64))
-		checkError(err)
-		_, err = file.WriteString("\n")
-		checkError(err)
+func leReais(s string) ([]float64, error) {
+	campos := strings.Split(s, ",")
+	vs := make([]float64, len(campos))
+	for i, c := range campos {
+		v, err := strconv.ParseFloat(strings.TrimSpace(c), 64)
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = v
 	}
+	return vs, nil
+}
 
-	// write some text to file
-	// _, err = file.WriteString("halo\n")
-	// checkError(err)
-	// _, err = file.WriteString("mari belajar golang\n")
-	// checkError(err)
+//formataRuido grava o nome do modelo seguido dos parâmetros em ordem alfabética, ex.: "student-t nu=5 sigma=0.3"
+func formataRuido(r Ruido) string {
+	parametros := r.Parametros()
+	nomes := make([]string, 0, len(parametros))
+	for nome := range parametros {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
 
-	// save changes
-	err = file.Sync()
-	checkError(err)
+	s := r.Nome()
+	for _, nome := range nomes {
+		s += " " + nome + "=" + formataReal(parametros[nome])
+	}
+	return s
 }
 
-func checkError(err error) {
-	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(0)
+//leRuido reconstrói o modelo de ruído gravado por formataRuido
+func leRuido(s string) (Ruido, error) {
+	campos := strings.Fields(s)
+	if len(campos) == 0 {
+		return nil, errors.New("modelo de ruído vazio")
+	}
+	parametros := map[string]float64{}
+	for _, c := range campos[1:] {
+		kv := strings.SplitN(c, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("parâmetro de ruído %q não está no formato nome=valor", c)
+		}
+		v, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		parametros[kv[0]] = v
 	}
+	return NovoRuido(campos[0], parametros)
 }
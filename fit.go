@@ -1,26 +1,140 @@
-package main
+package lfdoverfitting
 
 import (
+	"errors"
+	"fmt"
 	"math"
 
-	"github.com/gonum/matrix/mat64"
+	"github.com/rgarrot/lfdoverfitting/legendre"
+	"gonum.org/v1/gonum/mat"
 )
 
-func polyfit(b Base, n int) []float64 {
-	x := xPolyMatrix(b, n)
-	y := mat64.NewDense(len(b.Y), 1, b.Y)
-	var result mat64.Dense
-	result.Solve(x, y)
-	return result.RawMatrix().Data
+//Ajuste resultado do ajuste por mínimos quadrados de um polinômio de grau Grau
+type Ajuste struct {
+	Grau          int
+	Regularizacao Regularizacao //weight decay usado; Lambda = 0 é mínimos quadrados puro
+	Legendre      []float64     //g = Legendre[0]L_0(x) + ... + Legendre[n]L_n(x)
+	Monomial      Poly          //g = Monomial[0]x^0 + ... + Monomial[n]x^n; nil acima de GrauMaximoLegendre
+	Condicao      float64       //número de condição da matriz de projeto (sigma_max / sigma_min), +Inf se singular
+	Posto         int           //valores singulares acima da tolerância; menor que Grau+1 quando a base não determina g
+	Residuo       float64       //||Xg - y||, norma euclidiana do resíduo nos pontos da base
+	Efetivos      float64       //número efetivo de parâmetros, traço da matriz chapéu H = X (X^T X + Lambda Gamma)^-1 X^T
+	Alavancas     []float64     //diagonal H_ii da matriz chapéu, um valor por ponto da base
 }
 
-func xPolyMatrix(b Base, n int) mat64.Matrix {
+//Polyfit ajusta por mínimos quadrados um polinômio de grau n à base.
+//Retorna os indices do polinômio. Ex.: g[0]x^0 + g[1]x^1 + ... + g[n]x^n.
+func Polyfit(b Base, n int) (Poly, error) {
+	a, err := AjustaLegendre(b, n)
+	if err != nil {
+		return nil, err
+	}
+	if a.Monomial == nil {
+		return nil, fmt.Errorf("ajuste: grau %d acima de %d não tem forma em monômios", n, GrauMaximoLegendre)
+	}
+	return a.Monomial, nil
+}
+
+//AjustaLegendre ajusta por mínimos quadrados um polinômio de grau n à base usando a matriz de projeto
+//X[i][k] = L_k(x_i), bem condicionada para x em [-1;1], resolvida pela decomposição SVD.
+//Valores singulares abaixo de max(N, n+1) * eps * sigma_max são descartados; sem posto completo
+//o resultado é a solução de norma mínima, e Posto o indica.
+func AjustaLegendre(b Base, n int) (Ajuste, error) {
+	return AjustaRegularizado(b, n, Regularizacao{})
+}
+
+//AjustaRegularizado ajusta um polinômio de grau n com weight decay: minimiza
+//||Xw - y||² + Lambda * sum_q gamma_q w_q² sobre os coeficientes de legendre w, isto é, E_in + (Lambda/N) Omega(w).
+//O sistema aumentado [X; sqrt(Lambda Gamma)] w ~ [y; 0] é resolvido pela SVD como em AjustaLegendre.
+func AjustaRegularizado(b Base, n int, r Regularizacao) (Ajuste, error) {
+	a := Ajuste{Grau: n, Regularizacao: r}
+	if n < 0 {
+		return a, fmt.Errorf("ajuste: grau %d negativo", n)
+	}
+	if len(b.X) == 0 {
+		return a, errors.New("ajuste: base vazia")
+	}
+	gamma, err := r.pesos(n)
+	if err != nil {
+		return a, err
+	}
+
 	m := len(b.X)
-	x := make([]float64, (n+1)*m)
-	for r := 0; r < m; r++ {
-		for c := 0; c < (n + 1); c++ {
-			x[r*(n+1)+c] = math.Pow(b.X[r], float64(c))
+	linhas := m
+	if r.Lambda > 0 {
+		linhas += n + 1
+	}
+	x := mat.NewDense(linhas, n+1, legendreMatrix(b, n, linhas))
+	if r.Lambda > 0 {
+		for q, gq := range gamma {
+			x.Set(m+q, q, math.Sqrt(r.Lambda*gq))
+		}
+	}
+
+	var svd mat.SVD
+	if ok := svd.Factorize(x, mat.SVDThin); !ok {
+		return a, errors.New("ajuste: a decomposição SVD não convergiu")
+	}
+	s := svd.Values(nil)
+	var u, v mat.Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+
+	a.Condicao = s[0] / s[len(s)-1]
+	if len(s) < n+1 {
+		a.Condicao = math.Inf(1)
+	}
+	tol := float64(linhas) * eps * s[0]
+	if linhas < n+1 {
+		tol = float64(n+1) * eps * s[0]
+	}
+
+	//w = V * diag(1/s) * U^T * [y; 0], só com os valores singulares acima de tol.
+	//As m primeiras linhas de U formam U1 e H = U1 U1^T, logo traço(H) = ||U1||².
+	a.Legendre = make([]float64, n+1)
+	a.Alavancas = make([]float64, m)
+	for k, sk := range s {
+		if sk <= tol {
+			break
 		}
+		a.Posto++
+		uty := 0.0
+		for i, yi := range b.Y {
+			uki := u.At(i, k)
+			uty += uki * yi
+			a.Alavancas[i] += uki * uki
+			a.Efetivos += uki * uki
+		}
+		for j := range a.Legendre {
+			a.Legendre[j] += v.At(j, k) * uty / sk
+		}
+	}
+
+	soma := 0.0
+	for i, xi := range b.X {
+		d := AvaliaLegendre(a.Legendre, xi) - b.Y[i]
+		soma += d * d
+	}
+	a.Residuo = math.Sqrt(soma)
+
+	//os monômios transbordam float64 nos graus altos; o ajuste continua válido na base de legendre
+	if n <= GrauMaximoLegendre {
+		if a.Monomial, err = PolyLegendre(a.Legendre); err != nil {
+			return a, err
+		}
+	}
+	return a, nil
+}
+
+//eps épsilon da máquina para float64
+var eps = math.Nextafter(1, 2) - 1
+
+//legendreMatrix dados de uma matriz linhas x (n+1) com L_0(x_i)..L_n(x_i) na linha i;
+//as linhas além dos pontos da base ficam zeradas
+func legendreMatrix(b Base, n int, linhas int) []float64 {
+	x := make([]float64, (n+1)*linhas)
+	for r := range b.X {
+		legendre.Valores(b.X[r], x[r*(n+1):(r+1)*(n+1)])
 	}
-	return mat64.NewDense(m, (n + 1), x)
+	return x
 }
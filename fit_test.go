@@ -0,0 +1,114 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+func TestAjustaLegendre(t *testing.T) {
+	//sem ruído e com grau >= Qf o ajuste recupera os coeficientes do alvo
+	b := GeraBase(NovoRNG(5), 12, 60, ruidoGaussiano(0))
+	a, err := AjustaLegendre(b, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Posto != 13 {
+		t.Errorf("got posto %d; want 13", a.Posto)
+	}
+	for q := range b.A {
+		if math.Abs(a.Legendre[q]-b.A[q]) > 1e-10 {
+			t.Errorf("a_%d: got %v; want %v", q, a.Legendre[q], b.A[q])
+		}
+	}
+	if a.Residuo > 1e-10 {
+		t.Errorf("got resíduo %v; want 0", a.Residuo)
+	}
+
+	//menos pontos que coeficientes: posto N e condição infinita
+	a, err = AjustaLegendre(b.subBase([]int{0, 1, 2, 3, 4}), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Posto != 5 || !math.IsInf(a.Condicao, 1) {
+		t.Errorf("got posto %d, condição %v; want 5, +Inf", a.Posto, a.Condicao)
+	}
+}
+
+func TestAjustaRegularizado(t *testing.T) {
+	b := GeraBase(NovoRNG(6), 10, 30, ruidoGaussiano(0.5))
+	const grau = 6
+
+	//confere com as equações normais (X^T X + lambda Gamma) w = X^T y
+	for _, r := range []Regularizacao{{Lambda: 0.5}, {Lambda: 2, Penalidade: PenalidadeLegendre}} {
+		a, err := AjustaRegularizado(b, grau, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gamma, _ := r.pesos(grau)
+		p := make([]float64, grau+1)
+		for j := 0; j <= grau; j++ {
+			//gradiente de ||Xw - y||² + lambda sum gamma w² na coordenada j deve ser nulo
+			g := r.Lambda * gamma[j] * a.Legendre[j]
+			for i, x := range b.X {
+				legendre.Valores(x, p)
+				g += p[j] * (AvaliaLegendre(a.Legendre, x) - b.Y[i])
+			}
+			if math.Abs(g) > 1e-9 {
+				t.Errorf("%s: gradiente %v na coordenada %d", r, g, j)
+			}
+		}
+	}
+
+	//parâmetros efetivos: grau+1 sem regularização, decrescendo com lambda
+	anterior := math.Inf(1)
+	for _, lambda := range []float64{0, 0.01, 1, 100} {
+		a, err := AjustaRegularizado(b, grau, Regularizacao{Lambda: lambda})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lambda == 0 && math.Abs(a.Efetivos-grau-1) > 1e-9 {
+			t.Errorf("lambda 0: got %v parâmetros efetivos; want %d", a.Efetivos, grau+1)
+		}
+		if a.Efetivos >= anterior {
+			t.Errorf("lambda %v: %v parâmetros efetivos não diminuíram de %v", lambda, a.Efetivos, anterior)
+		}
+		anterior = a.Efetivos
+	}
+}
+
+func TestVarreLambdaGrauAlto(t *testing.T) {
+	//no grau 60 os monômios já não representam g; E_in tem de vir da série de legendre
+	b := GeraBase(NovoRNG(7), 60, 300, ruidoGaussiano(0.1))
+	c, err := VarreLambda(b, 60, PenalidadeLegendre, []float64{0, 1e-4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range c {
+		soma := 0.0
+		a, _ := AjustaRegularizado(b, 60, Regularizacao{Lambda: p.Lambda, Penalidade: PenalidadeLegendre})
+		for i, x := range b.X {
+			d := AvaliaLegendre(a.Legendre, x) - b.Y[i]
+			soma += d * d
+		}
+		if want := soma / float64(len(b.X)); math.Abs(p.Ein-want) > 1e-9*want || p.Ein > 0.01 {
+			t.Errorf("lambda %v: ein = %v; want %v, abaixo de sigma² = 0.01", p.Lambda, p.Ein, want)
+		}
+	}
+}
+
+func TestAjustaGrauAcimaDosMonomios(t *testing.T) {
+	//acima de GrauMaximoLegendre o ajuste vale na base de legendre, sem a forma em monômios
+	b := GeraBase(NovoRNG(9), 5, 50, ruidoGaussiano(0.1))
+	a, err := AjustaLegendre(b, GrauMaximoLegendre+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Legendre) != GrauMaximoLegendre+2 || a.Monomial != nil {
+		t.Errorf("got %d coeficientes de legendre e monômios %v", len(a.Legendre), a.Monomial != nil)
+	}
+	if _, err := Polyfit(b, GrauMaximoLegendre+1); err == nil {
+		t.Error("Polyfit acima de GrauMaximoLegendre aceito")
+	}
+}
@@ -1,62 +1,89 @@
-package main
+package lfdoverfitting
 
 import (
 	"math"
 	"math/big"
-)
 
-//MatrizLegendre com os coeficientes das funções de legendre
-var MatrizLegendre [][]*big.Float
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
 
 const prec = 200
 
-//CriaMatrizLegendre calcula coeficientes da MatrizLegendre
-func criaMatrizLegendre(n int) {
+//CriaMatrizLegendre coeficientes de monômios dos polinômios de legendre até o grau n em big.Float,
+//m[k][i] coeficiente de x^i em P_k. É a construção original da tabela, mantida para comparação com a
+//tabela racional exata; os ajustes usam a LegendreBasis. Grau negativo devolve a matriz vazia.
+func CriaMatrizLegendre(n int) [][]*big.Float {
+	if n < 0 {
+		return [][]*big.Float{}
+	}
 	n++
-	MatrizLegendre = make([][]*big.Float, n)
+	m := make([][]*big.Float, n)
 	for i := 0; i < n; i++ {
-		MatrizLegendre[i] = make([]*big.Float, n)
+		m[i] = make([]*big.Float, n)
 	}
 
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
-			MatrizLegendre[i][j] = new(big.Float).SetPrec(prec).SetFloat64(0.0)
+			m[i][j] = new(big.Float).SetPrec(prec).SetFloat64(0.0)
 		}
 	}
 
-	MatrizLegendre[0][0] = new(big.Float).SetPrec(prec).SetFloat64(1.0)
-	MatrizLegendre[1][1] = new(big.Float).SetPrec(prec).SetFloat64(1.0)
+	m[0][0] = new(big.Float).SetPrec(prec).SetFloat64(1.0)
+	if n > 1 {
+		m[1][1] = new(big.Float).SetPrec(prec).SetFloat64(1.0)
+	}
 
 	for k := 2; k < n; k++ {
 		for i := 0; i < k; i++ {
-			a := new(big.Float).SetPrec(prec).Set(MatrizLegendre[k-1][i])
+			a := new(big.Float).SetPrec(prec).Set(m[k-1][i])
 			b := new(big.Float).SetPrec(prec).SetFloat64((2.0*float64(k) - 1.0) / float64(k))
 			a.Mul(a, b)
-			MatrizLegendre[k][i+1].Add(MatrizLegendre[k][i+1], a)
+			m[k][i+1].Add(m[k][i+1], a)
 
-			c := new(big.Float).SetPrec(prec).Set(MatrizLegendre[k-2][i])
+			c := new(big.Float).SetPrec(prec).Set(m[k-2][i])
 			d := ((float64(k) - 1.0) / float64(k))
 			c.Mul(c, new(big.Float).SetFloat64(d))
-			MatrizLegendre[k][i].Sub(MatrizLegendre[k][i], c)
+			m[k][i].Sub(m[k][i], c)
 
-			//MatrizLegendre[k][i+1] += MatrizLegendre[k-1][i] * ((new(big.Float).SetFloat64(float64(2*k)) - new(big.Float).SetFloat64(1.0)) / new(big.Float).SetFloat64(float64(k)))
-			//MatrizLegendre[k][i] -= MatrizLegendre[k-2][i] * ((float64(k) - 1.0) / float64(k))
+			//m[k][i+1] += m[k-1][i] * ((new(big.Float).SetFloat64(float64(2*k)) - new(big.Float).SetFloat64(1.0)) / new(big.Float).SetFloat64(float64(k)))
+			//m[k][i] -= m[k-2][i] * ((float64(k) - 1.0) / float64(k))
 		}
 	}
+	return m
 }
 
-// Legendre polinomio de legendre grau k no ponto x
-// Apenas se a MatrizLegendre estiver inicializada até este valor.
-func legendre(k int, x float64) float64 {
-	//if len(MatrizLegendre) < k {
-	//	return 0.0
-	//	}
-	result := new(big.Float).SetPrec(prec).SetFloat64(0.0)
-	for i := 0; i <= k; i++ {
-		a := new(big.Float).SetPrec(prec).SetFloat64(math.Pow(x, float64(i)))
-		a.Mul(a, MatrizLegendre[k][i])
-		result.Add(result, a)
+//ErroMatrizLegendre maior erro relativo dos coeficientes da matriz m de CriaMatrizLegendre em cada grau,
+//em relação à tabela exata t. CriaMatrizLegendre multiplica por razões como (2k-1)/k já arredondadas
+//para float64, e o erro cresce com o grau apesar dos 200 bits de precisão de m.
+func ErroMatrizLegendre(m [][]*big.Float, t *legendre.TabelaRacional) ([]float64, error) {
+	erros := make([]float64, len(m))
+	for k, linha := range m {
+		exatos, err := t.Racional(k)
+		if err != nil {
+			return nil, err
+		}
+		for i, mi := range linha {
+			d, _ := mi.Rat(nil)
+			if i > k {
+				//acima da diagonal a matriz deve ser nula; o erro é absoluto
+				e, _ := d.Abs(d).Float64()
+				erros[k] = math.Max(erros[k], e)
+				continue
+			}
+			d.Sub(d, exatos[i])
+			e, _ := d.Abs(d).Float64()
+			if exatos[i].Sign() != 0 {
+				ref, _ := new(big.Rat).Abs(exatos[i]).Float64()
+				e /= ref
+			}
+			erros[k] = math.Max(erros[k], e)
+		}
 	}
-	resultFloat, _ := result.Float64()
-	return resultFloat
+	return erros, nil
+}
+
+//AvaliaLegendre calcula sum_k ( a[k] * Legendre_k(x) ) pelo algoritmo de Clenshaw de legendre.Serie,
+//sem passar pelos coeficientes de monômios
+func AvaliaLegendre(a []float64, x float64) float64 {
+	return legendre.Serie(a).Avalia(x)
 }
@@ -2,23 +2,205 @@ package legendre
 
 //Calculate legendre polynomial of degree k
 func Legendre(k int, x float64) float64 {
-  switch k {
-    case 0:
-      return float64(1)
-    case 1:
-      return x
-    default:
-      return legendre_recursive(k, x, 1, x, 0, 1)
-  }
-}
-
-func legendre_recursive(k int, x float64, kMenos1 int, lkMenos1 float64, kMenos2 int, lkMenos2 float64) float64 {
-    var kAtual float64 = float64(kMenos1+1);
-    var lkAtual float64 = (((2 * kAtual - 1) / kAtual ) * x * lkMenos1) - (((kAtual - 1)/kAtual) * lkMenos2)
-    if kMenos1 == (k-1) {
-      return lkAtual
-    } else {
-      return legendre_recursive(k, x, (kMenos1+1), lkAtual, kMenos1, lkMenos1);
-    }
-    return 0
+	if k < 0 {
+		return 0
+	}
+	p0, p1 := 1.0, x
+	if k == 0 {
+		return p0
+	}
+	for j := 2; j <= k; j++ {
+		p0, p1 = p1, proximo(j, x, p1, p0)
+	}
+	return p1
+}
+
+//proximo P_k(x) a partir de P_{k-1}(x) e P_{k-2}(x): k P_k = (2k-1) x P_{k-1} - (k-1) P_{k-2}
+func proximo(k int, x float64, pkMenos1 float64, pkMenos2 float64) float64 {
+	return (float64(2*k-1)*x*pkMenos1 - float64(k-1)*pkMenos2) / float64(k)
+}
+
+//Valores preenche p[k] = P_k(x) para k = 0..len(p)-1 numa única passada da recorrência
+func Valores(x float64, p []float64) {
+	for k := range p {
+		switch k {
+		case 0:
+			p[k] = 1
+		case 1:
+			p[k] = x
+		default:
+			p[k] = proximo(k, x, p[k-1], p[k-2])
+		}
+	}
+}
+
+//Serie combinação sum_k ( s[k] * P_k(x) ) de polinômios de legendre.
+//As operações devolvem séries novas e não alteram os operandos.
+type Serie []float64
+
+//Grau índice do maior coeficiente não nulo; -1 para a série nula
+func (s Serie) Grau() int {
+	for k := len(s) - 1; k >= 0; k-- {
+		if s[k] != 0 {
+			return k
+		}
+	}
+	return -1
+}
+
+//Avalia s(x) pelo algoritmo de Clenshaw sobre a recorrência
+//(k+1) P_{k+1}(x) = (2k+1) x P_k(x) - k P_{k-1}(x), sem passar pelos coeficientes de monômios
+func (s Serie) Avalia(x float64) float64 {
+	b1, b2 := 0.0, 0.0 //b_{k+1}, b_{k+2}
+	for k := len(s) - 1; k >= 0; k-- {
+		alfa := float64(2*k+1) * x / float64(k+1)
+		beta := -float64(k+1) / float64(k+2)
+		b1, b2 = s[k]+alfa*b1+beta*b2, b1
+	}
+	return b1
+}
+
+//AvaliaVarios s(xs[i]) para cada ponto, gravados em dst se ele tiver o tamanho de xs
+func (s Serie) AvaliaVarios(xs []float64, dst []float64) []float64 {
+	if len(dst) != len(xs) {
+		dst = make([]float64, len(xs))
+	}
+	for i, x := range xs {
+		dst[i] = s.Avalia(x)
+	}
+	return dst
+}
+
+//Soma s(x) + t(x)
+func (s Serie) Soma(t Serie) Serie {
+	if len(t) > len(s) {
+		s, t = t, s
+	}
+	r := append(Serie{}, s...)
+	for k, c := range t {
+		r[k] += c
+	}
+	return r
+}
+
+//Escala c * s(x)
+func (s Serie) Escala(c float64) Serie {
+	r := make(Serie, len(s))
+	for k, sk := range s {
+		r[k] = c * sk
+	}
+	return r
+}
+
+//Derivada s'(x), de P_{k+1}' - P_{k-1}' = (2k+1) P_k aplicada do grau mais alto para o mais baixo
+func (s Serie) Derivada() Serie {
+	n := len(s) - 1
+	if n < 1 {
+		return Serie{}
+	}
+	c := append(Serie{}, s...)
+	d := make(Serie, n)
+	for k := n; k >= 1; k-- {
+		d[k-1] = float64(2*k-1) * c[k]
+		if k >= 2 {
+			c[k-2] += c[k]
+		}
+	}
+	return d
+}
+
+//Primitiva S(x) com S' = s e S(-1) = 0, de Integral( P_k ) = (P_{k+1} - P_{k-1}) / (2k+1),
+//que se anula em -1 para k >= 1, e de Integral{-1^x}( P_0 ) = P_1 + P_0
+func (s Serie) Primitiva() Serie {
+	if len(s) == 0 {
+		return Serie{}
+	}
+	r := make(Serie, len(s)+1)
+	r[0], r[1] = s[0], s[0]
+	for k := 1; k < len(s); k++ {
+		c := s[k] / float64(2*k+1)
+		r[k+1] += c
+		r[k-1] -= c
+	}
+	return r
+}
+
+//Integral{a^b}( s(x) )
+func (s Serie) Integral(a float64, b float64) float64 {
+	S := s.Primitiva()
+	return S.Avalia(b) - S.Avalia(a)
+}
+
+//Multiplica s(x) * t(x) na base de legendre pela linearização de Adams-Neumann:
+//P_m P_n = sum_{j=0}^{min(m,n)} A_{m-j} A_j A_{n-j} / A_{m+n-j} * (2m+2n-4j+1)/(2m+2n-2j+1) P_{m+n-2j},
+//com A_r = (2r-1)!!/r!. Usa A_r / 2^r, que decresce como 1/sqrt(r) e não transborda; as potências de 2 se cancelam.
+func (s Serie) Multiplica(t Serie) Serie {
+	if len(s) == 0 || len(t) == 0 {
+		return Serie{}
+	}
+	grau := len(s) + len(t) - 2
+	a := make([]float64, grau+1)
+	a[0] = 1
+	for r := 1; r <= grau; r++ {
+		a[r] = a[r-1] * float64(2*r-1) / float64(2*r)
+	}
+
+	p := make(Serie, grau+1)
+	for m, sm := range s {
+		if sm == 0 {
+			continue
+		}
+		for n, tn := range t {
+			if tn == 0 {
+				continue
+			}
+			menor := m
+			if n < menor {
+				menor = n
+			}
+			for j := 0; j <= menor; j++ {
+				c := a[m-j] * a[j] * a[n-j] / a[m+n-j] * float64(2*(m+n)-4*j+1) / float64(2*(m+n)-2*j+1)
+				p[m+n-2*j] += sm * tn * c
+			}
+		}
+	}
+	return p
+}
+
+//tabelaMonomios tabela compartilhada por Serie.Monomios e pela LegendreBasis padrão do pacote principal
+var tabelaMonomios = NovaTabelaFloat64()
+
+//TabelaMonomios tabela usada por Serie.Monomios; quem a compartilha reaproveita os graus já convertidos
+func TabelaMonomios() *TabelaFloat64 {
+	return tabelaMonomios
+}
+
+//Monomios coeficientes c de s(x) = c[0]x^0 + ... + c[n]x^n, com os coeficientes de P_k da TabelaRacional
+//exata arredondados uma única vez para float64 e guardados em TabelaMonomios.
+//Para graus altos eles crescem e se cancelam, e os monômios perdem a precisão da série; a partir do
+//grau 814 eles transbordam float64 e a conversão falha.
+func (s Serie) Monomios() ([]float64, error) {
+	return tabelaMonomios.Monomios(s)
+}
+
+//DeMonomios série de legendre de c[0]x^0 + ... + c[n]x^n, por Horner com a multiplicação por x
+//feita na própria base: x P_k = ((k+1) P_{k+1} + k P_{k-1}) / (2k+1)
+func DeMonomios(c []float64) Serie {
+	s := make(Serie, len(c))
+	for i := len(c) - 1; i >= 0; i-- {
+		//s <- x * s + c[i], com s de grau len(c)-1-i
+		r := make(Serie, len(c))
+		for k := 0; k < len(c)-1-i; k++ {
+			if s[k] == 0 {
+				continue
+			}
+			r[k+1] += s[k] * float64(k+1) / float64(2*k+1)
+			if k > 0 {
+				r[k-1] += s[k] * float64(k) / float64(2*k+1)
+			}
+		}
+		r[0] += c[i]
+		s = r
+	}
+	return s
 }
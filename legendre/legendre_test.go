@@ -0,0 +1,80 @@
+package legendre
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSerie(t *testing.T) {
+	s := Serie{0.3, -1.2, 0.5, 0, 2}
+	u := Serie{1, 0.25, -0.7}
+	perto := func(nome string, got, want float64) {
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: got %v; want %v", nome, got, want)
+		}
+	}
+
+	xs := []float64{-1, -0.6, 0.1, 0.85, 1}
+	ys := s.AvaliaVarios(xs, nil)
+	p := make([]float64, len(s))
+	for i, x := range xs {
+		Valores(x, p)
+		want := 0.0
+		for k, sk := range s {
+			want += sk * p[k]
+			perto("valores", p[k], Legendre(k, x))
+		}
+		perto("avalia", ys[i], want)
+		perto("multiplica", s.Multiplica(u).Avalia(x), s.Avalia(x)*u.Avalia(x))
+		perto("soma", s.Soma(u).Avalia(x), s.Avalia(x)+u.Avalia(x))
+		perto("primitiva", s.Primitiva().Derivada().Avalia(x), s.Avalia(x))
+
+		//derivada por diferença central
+		h := 1e-5
+		d := (s.Avalia(x+h) - s.Avalia(x-h)) / (2 * h)
+		if got := s.Derivada().Avalia(x); math.Abs(got-d) > 1e-6 {
+			t.Errorf("derivada em %v: got %v; want %v", x, got, d)
+		}
+	}
+	perto("primitiva em -1", s.Primitiva().Avalia(-1), 0)
+	//só P_0 tem integral não nula em [-1;1]
+	perto("integral", s.Integral(-1, 1), 2*s[0])
+
+	c, err := s.Monomios()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range xs {
+		m := 0.0
+		for i := len(c) - 1; i >= 0; i-- {
+			m = m*x + c[i]
+		}
+		perto("monomios", m, s.Avalia(x))
+	}
+	for k, v := range DeMonomios(c) {
+		perto("ida e volta", v, s[k])
+	}
+
+	//P_2 = (3x² - 1)/2
+	p2, err := Serie{0, 0, 1}.Monomios()
+	if err != nil {
+		t.Fatal(err)
+	}
+	perto("P_2 x^0", p2[0], -0.5)
+	perto("P_2 x^2", p2[2], 1.5)
+	if g := (Serie{1, 2, 0, 0}).Grau(); g != 1 {
+		t.Errorf("grau: got %d; want 1", g)
+	}
+}
+
+func TestMultiplicaGrauAlto(t *testing.T) {
+	//P_m P_n de grau alto, onde A_r = (2r-1)!!/r! transbordaria em float64
+	m, n := make(Serie, 601), make(Serie, 501)
+	m[600], n[500] = 1, 1
+	prod := m.Multiplica(n)
+	for _, x := range []float64{-0.9, 0.2, 0.77} {
+		if got, want := prod.Avalia(x), Legendre(600, x)*Legendre(500, x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("P_600 P_500 em %v: got %v; want %v", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package legendre
+
+import (
+	"fmt"
+	"math"
+)
+
+//Quadratura regra de Gauss-Legendre de ordem n: Integral{-1^1}( f(x) ) ~ sum_i Pesos[i] f(Nos[i]),
+//exata para polinômios de grau até 2n-1
+type Quadratura struct {
+	Nos   []float64 //raízes de P_n em ordem crescente
+	Pesos []float64
+}
+
+//NovaQuadratura calcula os nós e pesos da regra de ordem n. Cada raiz de P_n parte da aproximação
+//x = cos(pi (i - 1/4) / (n + 1/2)) e é refinada pelo método de Newton, com P_n e P_n' obtidos pela
+//recorrência de três termos; o peso é 2 / ((1 - x²) P_n'(x)²). Só metade das raízes é calculada,
+//a outra sai da simetria x -> -x.
+func NovaQuadratura(n int) (Quadratura, error) {
+	if n < 1 {
+		return Quadratura{}, fmt.Errorf("quadratura: ordem %d deve ser positiva", n)
+	}
+	q := Quadratura{Nos: make([]float64, n), Pesos: make([]float64, n)}
+	for i := 0; i < (n+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			var p float64
+			p, dp = valorDerivada(n, x)
+			dx := p / dp
+			x -= dx
+			if math.Abs(dx) <= 2e-16*math.Abs(x) {
+				break
+			}
+		}
+		_, dp = valorDerivada(n, x)
+		w := 2 / ((1 - x*x) * dp * dp)
+		q.Nos[i], q.Nos[n-1-i] = -x, x
+		q.Pesos[i], q.Pesos[n-1-i] = w, w
+	}
+	if n%2 == 1 {
+		q.Nos[n/2] = 0
+	}
+	return q, nil
+}
+
+//valorDerivada P_n(x) e P_n'(x) pela recorrência k P_k = (2k-1) x P_{k-1} - (k-1) P_{k-2}
+//e por P_n' = n (x P_n - P_{n-1}) / (x² - 1), válida para |x| < 1
+func valorDerivada(n int, x float64) (float64, float64) {
+	p0, p1 := 1.0, x
+	if n == 0 {
+		return 1, 0
+	}
+	for k := 2; k <= n; k++ {
+		kf := float64(k)
+		p0, p1 = p1, ((2*kf-1)*x*p1-(kf-1)*p0)/kf
+	}
+	return p1, float64(n) * (x*p1 - p0) / (x*x - 1)
+}
+
+//Integra aproxima Integral{a^b}( f(x) ) mapeando os nós de [-1;1] para [a;b]
+func (q Quadratura) Integra(f func(x float64) float64, a float64, b float64) float64 {
+	meio, raio := (a+b)/2, (b-a)/2
+	soma := 0.0
+	for i, x := range q.Nos {
+		soma += q.Pesos[i] * f(meio+raio*x)
+	}
+	return raio * soma
+}
+
+//IntegraComposta divide [a;b] em partes intervalos iguais e aplica a regra em cada um.
+//Para integrandos apenas contínuos por partes (predições limitadas, splines) escolha partes
+//de modo que as quebras caiam nas bordas dos intervalos, ou aumente partes até a soma estabilizar.
+func (q Quadratura) IntegraComposta(f func(x float64) float64, a float64, b float64, partes int) float64 {
+	if partes < 1 {
+		partes = 1
+	}
+	h := (b - a) / float64(partes)
+	soma := 0.0
+	for k := 0; k < partes; k++ {
+		soma += q.Integra(f, a+float64(k)*h, a+float64(k+1)*h)
+	}
+	return soma
+}
+
+//Integra aproxima Integral{a^b}( f(x) ) pela regra de Gauss-Legendre de ordem n
+func Integra(f func(x float64) float64, a float64, b float64, n int) (float64, error) {
+	q, err := NovaQuadratura(n)
+	if err != nil {
+		return 0, err
+	}
+	return q.Integra(f, a, b), nil
+}
@@ -0,0 +1,62 @@
+package legendre
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNovaQuadratura(t *testing.T) {
+	//nós e pesos tabelados da regra de ordem 3
+	q, err := NovaQuadratura(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := math.Sqrt(0.6)
+	nos, pesos := []float64{-r, 0, r}, []float64{5.0 / 9, 8.0 / 9, 5.0 / 9}
+	for i := range nos {
+		if math.Abs(q.Nos[i]-nos[i]) > 1e-15 || math.Abs(q.Pesos[i]-pesos[i]) > 1e-15 {
+			t.Errorf("nó %d: (%v, %v); want (%v, %v)", i, q.Nos[i], q.Pesos[i], nos[i], pesos[i])
+		}
+	}
+
+	for _, n := range []int{1, 2, 7, 20, 64, 200} {
+		q, err := NovaQuadratura(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		soma := 0.0
+		for i, x := range q.Nos {
+			soma += q.Pesos[i]
+			if i > 0 && x <= q.Nos[i-1] {
+				t.Errorf("n = %d: nós fora de ordem em %d", n, i)
+			}
+		}
+		if math.Abs(soma-2) > 1e-13 {
+			t.Errorf("n = %d: soma dos pesos = %v; want 2", n, soma)
+		}
+		//exata para x^(2n-2), cuja integral em [-1;1] é 2/(2n-1)
+		k := float64(2*n - 2)
+		got := q.Integra(func(x float64) float64 { return math.Pow(x, k) }, -1, 1)
+		if want := 2 / (k + 1); math.Abs(got-want) > 1e-13 {
+			t.Errorf("n = %d: integral de x^%v = %v; want %v", n, k, got, want)
+		}
+	}
+}
+
+func TestIntegra(t *testing.T) {
+	got, err := Integra(math.Exp, 0, 2, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.Exp(2) - 1; math.Abs(got-want) > 1e-14*want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	q, _ := NovaQuadratura(4)
+	//|x| tem a quebra em 0, na borda entre as duas partes
+	if got := q.IntegraComposta(math.Abs, -1, 1, 2); math.Abs(got-1) > 1e-15 {
+		t.Errorf("integral de |x| = %v; want 1", got)
+	}
+	if _, err := Integra(math.Exp, 0, 1, 0); err == nil {
+		t.Error("ordem 0 deveria falhar")
+	}
+}
@@ -0,0 +1,191 @@
+package legendre
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/rgarrot/lfdoverfitting/float128"
+)
+
+//TabelaRacional coeficientes exatos dos polinômios de legendre, P_k(x) = sum_i C[k][i] x^i com C[k][i] racional.
+//Os graus crescem sob demanda. Não é segura para uso concorrente: quem compartilha a tabela entre goroutines
+//deve sincronizar os acessos.
+type TabelaRacional struct {
+	c [][]*big.Rat //c[k][i] coeficiente de x^i em P_k; nil quando i e k têm paridades diferentes
+}
+
+//NovaTabelaRacional tabela vazia; o primeiro acesso calcula os graus necessários
+func NovaTabelaRacional() *TabelaRacional {
+	return &TabelaRacional{}
+}
+
+//Grau maior grau já calculado; -1 para a tabela vazia
+func (t *TabelaRacional) Grau() int {
+	return len(t.c) - 1
+}
+
+//Cresce calcula os polinômios até o grau n. Cada P_k sai da forma fechada
+//C[k][k] = (2k)! / (2^k (k!)²) e C[k][i-2] = -C[k][i] i (i-1) / ((k-i+2) (k+i-1)),
+//uma multiplicação racional exata por coeficiente, sem arredondamento.
+func (t *TabelaRacional) Cresce(n int) {
+	for k := len(t.c); k <= n; k++ {
+		pk := make([]*big.Rat, k+1)
+		if k == 0 {
+			pk[0] = big.NewRat(1, 1)
+		} else {
+			//C[k][k] = C[k-1][k-1] (2k-1) / k
+			pk[k] = new(big.Rat).Mul(t.c[k-1][k-1], big.NewRat(int64(2*k-1), int64(k)))
+		}
+		for i := k; i >= 2; i -= 2 {
+			r := big.NewRat(-int64(i)*int64(i-1), int64(k-i+2)*int64(k+i-1))
+			pk[i-2] = new(big.Rat).Mul(pk[i], r)
+		}
+		t.c = append(t.c, pk)
+	}
+}
+
+//Racional coeficientes exatos de P_k, do grau 0 ao grau k; zeros onde a paridade anula o termo.
+//Os valores devolvidos são cópias.
+func (t *TabelaRacional) Racional(k int) ([]*big.Rat, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("tabela de legendre: grau %d negativo", k)
+	}
+	t.Cresce(k)
+	c := make([]*big.Rat, k+1)
+	for i, ci := range t.c[k] {
+		c[i] = new(big.Rat)
+		if ci != nil {
+			c[i].Set(ci)
+		}
+	}
+	return c, nil
+}
+
+//Float coeficientes de P_k arredondados uma única vez para big.Float de precisão prec
+func (t *TabelaRacional) Float(k int, prec uint) ([]*big.Float, error) {
+	r, err := t.Racional(k)
+	if err != nil {
+		return nil, err
+	}
+	c := make([]*big.Float, len(r))
+	for i, ri := range r {
+		c[i] = new(big.Float).SetPrec(prec).SetRat(ri)
+	}
+	return c, nil
+}
+
+//Float128 coeficientes de P_k como double-double: a parte alta é o float64 mais próximo
+//e a parte baixa o float64 mais próximo do resto exato. Falha, como Float64, quando a parte alta transborda.
+func (t *TabelaRacional) Float128(k int) ([]float128.Float128, error) {
+	r, err := t.Racional(k)
+	if err != nil {
+		return nil, err
+	}
+	c := make([]float128.Float128, len(r))
+	for i, ri := range r {
+		alto, _ := ri.Float64()
+		if math.IsInf(alto, 0) {
+			return nil, fmt.Errorf("tabela de legendre: coeficiente de x^%d em P_%d transborda float64", i, k)
+		}
+		resto := new(big.Rat).Sub(ri, new(big.Rat).SetFloat64(alto))
+		baixo, _ := resto.Float64()
+		c[i] = float128.SetFF(alto, baixo)
+	}
+	return c, nil
+}
+
+//Float64 coeficientes de P_k, cada um o float64 mais próximo do valor exato.
+//Falha quando algum coeficiente transborda float64, o que acontece a partir do grau 814.
+func (t *TabelaRacional) Float64(k int) ([]float64, error) {
+	r, err := t.Racional(k)
+	if err != nil {
+		return nil, err
+	}
+	c := make([]float64, len(r))
+	for i, ri := range r {
+		c[i], _ = ri.Float64()
+		if math.IsInf(c[i], 0) {
+			return nil, fmt.Errorf("tabela de legendre: coeficiente de x^%d em P_%d transborda float64", i, k)
+		}
+	}
+	return c, nil
+}
+
+//TabelaFloat64 coeficientes de monômios dos polinômios de legendre, C[k][i] de x^i em P_k, cada um o float64
+//mais próximo do racional exato de uma TabelaRacional, convertido uma única vez. Os graus crescem sob demanda
+//e a tabela pode ser compartilhada entre goroutines: leituras de graus já calculados só tomam o RLock.
+type TabelaFloat64 struct {
+	mu    sync.RWMutex
+	exata *TabelaRacional
+	coef  [][]float64
+}
+
+//NovaTabelaFloat64 tabela vazia; o primeiro acesso calcula os graus necessários
+func NovaTabelaFloat64() *TabelaFloat64 {
+	return &TabelaFloat64{exata: NovaTabelaRacional()}
+}
+
+//Grau maior grau já calculado; -1 para a tabela vazia
+func (t *TabelaFloat64) Grau() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.coef) - 1
+}
+
+//Cresce calcula os graus até n, se ainda faltarem. Falha a partir do grau 814, como TabelaRacional.Float64.
+func (t *TabelaFloat64) Cresce(n int) error {
+	if n < 0 {
+		return fmt.Errorf("tabela de legendre: grau %d negativo", n)
+	}
+	t.mu.RLock()
+	pronto := n < len(t.coef)
+	t.mu.RUnlock()
+	if pronto {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k := len(t.coef); k <= n; k++ {
+		c, err := t.exata.Float64(k)
+		if err != nil {
+			return err
+		}
+		t.coef = append(t.coef, c)
+	}
+	return nil
+}
+
+//Coeficientes C[k][0..k] de P_k. O slice é compartilhado com a tabela e não deve ser alterado.
+func (t *TabelaFloat64) Coeficientes(k int) ([]float64, error) {
+	if err := t.Cresce(k); err != nil {
+		return nil, err
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.coef[k], nil
+}
+
+//Monomios coeficientes c de s(x) = c[0]x^0 + ... + c[n]x^n, combinando as linhas da tabela
+func (t *TabelaFloat64) Monomios(s Serie) ([]float64, error) {
+	c := make([]float64, len(s))
+	if len(s) == 0 {
+		return c, nil
+	}
+	if err := t.Cresce(len(s) - 1); err != nil {
+		return nil, err
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for k, sk := range s {
+		if sk == 0 {
+			continue
+		}
+		for i, v := range t.coef[k] {
+			c[i] += sk * v
+		}
+	}
+	return c, nil
+}
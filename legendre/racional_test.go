@@ -0,0 +1,72 @@
+package legendre
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestTabelaRacional(t *testing.T) {
+	tab := NovaTabelaRacional()
+	if g := tab.Grau(); g != -1 {
+		t.Errorf("grau da tabela vazia: got %d; want -1", g)
+	}
+	//P_4 = (35x^4 - 30x^2 + 3) / 8
+	p4, err := tab.Racional(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*big.Rat{big.NewRat(3, 8), new(big.Rat), big.NewRat(-30, 8), new(big.Rat), big.NewRat(35, 8)}
+	for i := range want {
+		if p4[i].Cmp(want[i]) != 0 {
+			t.Errorf("P_4 x^%d: got %v; want %v", i, p4[i], want[i])
+		}
+	}
+	if g := tab.Grau(); g != 4 {
+		t.Errorf("grau depois de Racional(4): got %d; want 4", g)
+	}
+
+	//as cópias não alteram a tabela
+	p4[0].SetInt64(7)
+	if again, _ := tab.Racional(4); again[0].Cmp(big.NewRat(3, 8)) != 0 {
+		t.Errorf("Racional devolveu referência interna: P_4 x^0 = %v", again[0])
+	}
+
+	//P_k(1) = 1 exatamente
+	for _, k := range []int{10, 57, 150} {
+		c, _ := tab.Racional(k)
+		soma := new(big.Rat)
+		for _, ci := range c {
+			soma.Add(soma, ci)
+		}
+		if soma.Cmp(big.NewRat(1, 1)) != 0 {
+			t.Errorf("P_%d(1) = %v; want 1", k, soma)
+		}
+	}
+
+	c64, _ := tab.Float64(30)
+	c128, _ := tab.Float128(30)
+	exatos, _ := tab.Racional(30)
+	for i := range c64 {
+		if c64[i] != c128[i][0] {
+			t.Errorf("x^%d: parte alta %v difere do float64 %v", i, c128[i][0], c64[i])
+		}
+		//alto + baixo representa o racional com ~106 bits
+		soma := new(big.Rat).SetFloat64(c128[i][0])
+		soma.Add(soma, new(big.Rat).SetFloat64(c128[i][1]))
+		d, _ := soma.Sub(soma, exatos[i]).Float64()
+		if ref, _ := exatos[i].Float64(); math.Abs(d) > 1e-30*math.Abs(ref) {
+			t.Errorf("x^%d: erro do double-double %v", i, d)
+		}
+	}
+	cf, _ := tab.Float(30, 300)
+	for i := range cf {
+		if got, _ := cf[i].Float64(); got != c64[i] {
+			t.Errorf("x^%d: big.Float %v; want %v", i, got, c64[i])
+		}
+	}
+
+	if _, err := tab.Racional(-1); err == nil {
+		t.Error("grau negativo deveria falhar")
+	}
+}
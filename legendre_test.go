@@ -0,0 +1,83 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+//recorrencia P_k(x) pela recorrência de três termos direta
+func recorrencia(k int, x float64) float64 {
+	p0, p1 := 1.0, x
+	if k == 0 {
+		return p0
+	}
+	for j := 1; j < k; j++ {
+		p0, p1 = p1, (float64(2*j+1)*x*p1-float64(j)*p0)/float64(j+1)
+	}
+	return p1
+}
+
+func TestAvaliaLegendre(t *testing.T) {
+	for k := 0; k <= 30; k++ {
+		a := make([]float64, k+1)
+		a[k] = 1
+		for _, x := range []float64{-1, -0.7, -0.1, 0, 0.33, 0.9, 1} {
+			if got, want := AvaliaLegendre(a, x), recorrencia(k, x); math.Abs(got-want) > 1e-12 {
+				t.Errorf("P_%d(%v): got %v; want %v", k, x, got, want)
+			}
+		}
+	}
+
+	a := GeraAlvo(NovoRNG(3), 300).A
+	for _, x := range []float64{-0.95, -0.2, 0.41, 0.999} {
+		want := 0.0
+		for k, ak := range a {
+			want += ak * recorrencia(k, x)
+		}
+		if got := AvaliaLegendre(a, x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("série de grau 300 em %v: got %v; want %v", x, got, want)
+		}
+	}
+
+	//grau alto: P_k(1) = 1, P_k(-1) = (-1)^k e |P_k(x)| <= 1
+	for _, k := range []int{200, 501} {
+		a := make([]float64, k+1)
+		a[k] = 1
+		if got := AvaliaLegendre(a, 1); math.Abs(got-1) > 1e-9 {
+			t.Errorf("P_%d(1): got %v; want 1", k, got)
+		}
+		if got, want := AvaliaLegendre(a, -1), math.Pow(-1, float64(k)); math.Abs(got-want) > 1e-9 {
+			t.Errorf("P_%d(-1): got %v; want %v", k, got, want)
+		}
+		for x := -0.99; x < 1; x += 0.01 {
+			if got := AvaliaLegendre(a, x); math.Abs(got) > 1 {
+				t.Errorf("P_%d(%v): got %v; |P_k| <= 1", k, x, got)
+			}
+		}
+	}
+}
+
+func TestErroMatrizLegendre(t *testing.T) {
+	m := CriaMatrizLegendre(100)
+	erros, err := ErroMatrizLegendre(m, legendre.NovaTabelaRacional())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(erros) != len(m) {
+		t.Fatalf("len(erros) = %d; want %d", len(erros), len(m))
+	}
+	//até o grau 2 as razões (2k-1)/k e (k-1)/k são exatas em float64
+	for k := 0; k <= 2; k++ {
+		if erros[k] != 0 {
+			t.Errorf("grau %d: erro %v; want 0", k, erros[k])
+		}
+	}
+	if e := erros[len(erros)-1]; e == 0 || e > 1e-12 {
+		t.Errorf("grau %d: erro %v; want entre 0 e 1e-12", len(erros)-1, e)
+	}
+	if m := CriaMatrizLegendre(-1); len(m) != 0 {
+		t.Errorf("grau -1: got %d linhas; want 0", len(m))
+	}
+}
@@ -0,0 +1,207 @@
+package lfdoverfitting
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+//EixosMapa parâmetros da grade desenhados nos eixos x e y do mapa de calor
+type EixosMapa string
+
+const (
+	EixosNSigma EixosMapa = "n-sigma" //N por sigma, com Qf fixo
+	EixosNQf    EixosMapa = "n-qf"    //N por Qf, com sigma fixo
+)
+
+//MapaOverfit configuração do mapa de calor da medida de overfit sobre a tabela de um sweep,
+//como na figura 4.3 do Learning From Data. O valor zero usa os padrões.
+type MapaOverfit struct {
+	Eixos   EixosMapa //padrão EixosNSigma
+	Fixo    float64   //valor de Qf (n-sigma) ou de sigma (n-qf) mantido fixo
+	Titulo  string
+	Limite  float64   //a escala de cores vai de -Limite a Limite; 0 usa o maior |overfit| da grade
+	Largura vg.Length //padrão 5 polegadas, incluindo a barra de cores
+	Altura  vg.Length //padrão 4 polegadas
+}
+
+//gradeMapa overfit médio z[c][r] na célula (x[c], y[r]), recortado em [-limite;limite] para o desenho
+type gradeMapa struct {
+	x, y   []float64
+	z      [][]float64
+	limite float64
+}
+
+func (g gradeMapa) Dims() (c, r int) { return len(g.x), len(g.y) }
+func (g gradeMapa) X(c int) float64  { return g.x[c] }
+func (g gradeMapa) Y(r int) float64  { return g.y[r] }
+func (g gradeMapa) Min() float64     { return -g.limite }
+func (g gradeMapa) Max() float64     { return g.limite }
+
+func (g gradeMapa) Z(c, r int) float64 {
+	return math.Max(-g.limite, math.Min(g.limite, g.z[c][r]))
+}
+
+//eixos devolve o padrão EixosNSigma para o valor zero
+func (m MapaOverfit) eixos() EixosMapa {
+	if m.Eixos == "" {
+		return EixosNSigma
+	}
+	return m.Eixos
+}
+
+//rotulos nomes do parâmetro no eixo y e do parâmetro fixo
+func (m MapaOverfit) rotulos() (y string, fixo string) {
+	if m.eixos() == EixosNQf {
+		return "Qf", "sigma"
+	}
+	return "sigma", "Qf"
+}
+
+//grade monta o overfit médio das células da tabela com o parâmetro fixo igual a m.Fixo.
+//Todas as combinações dos valores de N e do eixo y presentes devem estar na tabela.
+func (m MapaOverfit) grade(t Tabela) (gradeMapa, error) {
+	var y func(r Resultado) float64
+	var fixo func(r Resultado) float64
+	switch m.eixos() {
+	case EixosNSigma:
+		y = func(r Resultado) float64 { return r.Sigma }
+		fixo = func(r Resultado) float64 { return float64(r.Qf) }
+	case EixosNQf:
+		y = func(r Resultado) float64 { return float64(r.Qf) }
+		fixo = func(r Resultado) float64 { return r.Sigma }
+	default:
+		return gradeMapa{}, fmt.Errorf("mapa: eixos %q desconhecidos; use %q ou %q", m.Eixos, EixosNSigma, EixosNQf)
+	}
+
+	type ponto struct{ x, y float64 }
+	valores := map[ponto]float64{}
+	xs, ys := map[float64]bool{}, map[float64]bool{}
+	for _, r := range t {
+		if math.Abs(fixo(r)-m.Fixo) > 1e-9 {
+			continue
+		}
+		p := ponto{float64(r.N), y(r)}
+		valores[p] = r.Media
+		xs[p.x], ys[p.y] = true, true
+	}
+	nomeY, nomeFixo := m.rotulos()
+	if len(xs) < 2 || len(ys) < 2 {
+		return gradeMapa{}, fmt.Errorf("mapa: a tabela precisa de ao menos 2x2 células com %s = %v", nomeFixo, m.Fixo)
+	}
+
+	g := gradeMapa{x: ordenados(xs), y: ordenados(ys), limite: m.Limite}
+	g.z = make([][]float64, len(g.x))
+	maior := 0.0
+	for c, x := range g.x {
+		g.z[c] = make([]float64, len(g.y))
+		for r, y := range g.y {
+			v, ok := valores[ponto{x, y}]
+			if !ok {
+				return gradeMapa{}, fmt.Errorf("mapa: falta a célula N = %v, %s = %v", x, nomeY, y)
+			}
+			g.z[c][r] = v
+			maior = math.Max(maior, math.Abs(v))
+		}
+	}
+	if g.limite <= 0 {
+		g.limite = maior
+	}
+	if g.limite == 0 {
+		g.limite = 1
+	}
+	return g, nil
+}
+
+//ordenados chaves de um conjunto em ordem crescente
+func ordenados(conjunto map[float64]bool) []float64 {
+	vs := make([]float64, 0, len(conjunto))
+	for v := range conjunto {
+		vs = append(vs, v)
+	}
+	sort.Float64s(vs)
+	return vs
+}
+
+//Desenha o mapa de calor do overfit médio com escala divergente azul-vermelho centrada em zero,
+//a curva de nível overfit = 0 e uma barra de cores à direita. O formato vem da extensão de path.
+func (m MapaOverfit) Desenha(path string, t Tabela) error {
+	if err := formatoSuportado(path); err != nil {
+		return err
+	}
+	g, err := m.grade(t)
+	if err != nil {
+		return err
+	}
+
+	cores := moreland.SmoothBlueRed()
+	cores.SetMin(-g.limite)
+	cores.SetMax(g.limite)
+	cores.SetConvergePoint(0)
+
+	p, err := plot.New()
+	if err != nil {
+		return err
+	}
+	nomeY, nomeFixo := m.rotulos()
+	p.Title.Text = m.Titulo
+	if p.Title.Text == "" {
+		p.Title.Text = fmt.Sprintf("Overfit, %s = %v", nomeFixo, m.Fixo)
+	}
+	p.X.Label.Text = "N"
+	p.Y.Label.Text = nomeY
+
+	h := plotter.NewHeatMap(g, cores.Palette(255))
+	h.Min, h.Max = -g.limite, g.limite
+	p.Add(h)
+
+	zero := plotter.NewContour(g, []float64{0}, nil)
+	zero.LineStyles = []draw.LineStyle{{Color: color.Black, Width: vg.Points(2)}}
+	p.Add(zero)
+
+	barra, err := plot.New()
+	if err != nil {
+		return err
+	}
+	barra.Add(&plotter.ColorBar{ColorMap: cores, Vertical: true})
+	barra.HideX()
+	barra.Y.Padding = 0
+	barra.Title.Text = "overfit"
+
+	largura, altura := m.Largura, m.Altura
+	if largura <= 0 {
+		largura = 5 * vg.Inch
+	}
+	if altura <= 0 {
+		altura = 4 * vg.Inch
+	}
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	c, err := draw.NewFormattedCanvas(largura, altura, ext)
+	if err != nil {
+		return err
+	}
+	dc := draw.New(c)
+	lb := largura / 5
+	p.Draw(draw.Crop(dc, 0, -lb, 0, 0))
+	barra.Draw(draw.Crop(dc, largura-lb, 0, 0, 0))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
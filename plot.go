@@ -1,54 +1,125 @@
-package main
+package lfdoverfitting
 
 import (
+	"fmt"
 	"image/color"
+	"path/filepath"
+	"strings"
 
-	"golearn/num"
-
-	"github.com/gonum/plot"
-	"github.com/gonum/plot/plotter"
-	"github.com/gonum/plot/vg"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
 )
 
-func plotBase(b Base, yP2 num.Matrix, yP10 num.Matrix) {
+//Curva função desenhada como linha contínua sobre [-1;1]
+type Curva struct {
+	Nome string
+	F    func(x float64) float64
+}
+
+//CurvaPolinomio curva de uma hipótese g[0]x^0 + g[1]x^1 + ... + g[n]x^n
+func CurvaPolinomio(nome string, g Poly) Curva {
+	return Curva{Nome: nome, F: g.Avalia}
+}
+
+//CurvaLegendre curva de uma hipótese sum_k ( a[k] * Legendre_k(x) ), avaliada na própria base de legendre;
+//nos graus altos os monômios de CurvaPolinomio já não representam a hipótese
+func CurvaLegendre(nome string, a []float64) Curva {
+	return Curva{Nome: nome, F: func(x float64) float64 { return AvaliaLegendre(a, x) }}
+}
+
+//Grafico configuração do gráfico da base, do alvo e das hipóteses. O valor zero usa os padrões.
+type Grafico struct {
+	Titulo   string
+	Largura  vg.Length //padrão 4 polegadas
+	Altura   vg.Length //padrão 4 polegadas
+	Amostras int       //pontos da grade densa em [-1;1], padrão 200
+	YMin     float64   //limites do eixo y; YMin == YMax escolhe automaticamente
+	YMax     float64
+}
+
+//formatos extensões aceitas por plot.Save
+var formatos = map[string]bool{"png": true, "svg": true, "pdf": true, "eps": true, "jpg": true, "jpeg": true, "tif": true, "tiff": true}
+
+//PlotBase desenha a base com as opções padrão; ver Grafico.Desenha
+func PlotBase(path string, b Base, hipoteses ...Curva) error {
+	return Grafico{}.Desenha(path, b, hipoteses...)
+}
+
+//Desenha os pontos da base, o alvo f sem ruído (se a base tiver alvo) e cada hipótese
+//avaliada numa grade densa sobre [-1;1]. O formato (png, svg, pdf, ...) vem da extensão de path.
+func (gr Grafico) Desenha(path string, b Base, hipoteses ...Curva) error {
+	if err := formatoSuportado(path); err != nil {
+		return err
+	}
+
 	p, err := plot.New()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	p.Title.Text = "Plot Base"
+	p.Title.Text = gr.Titulo
+	if p.Title.Text == "" {
+		p.Title.Text = "Plot Base"
+	}
 	p.X.Label.Text = "X"
 	p.Y.Label.Text = "Y"
+	p.X.Min, p.X.Max = -1, 1
+	p.Legend.Top = true
 
 	// Make a scatter plotter and set its style.
 	s, err := plotter.NewScatter(baseToPlotter(b))
 	if err != nil {
-		panic(err)
+		return err
 	}
 	s.GlyphStyle.Color = color.RGBA{R: 255, B: 128, A: 255}
-
 	p.Add(s)
+	p.Legend.Add("dados", s)
 
-	// Make a scatter plotter and set its style.
-	s, err = plotter.NewScatter(predictedToPlotter(b, yP2))
-	if err != nil {
-		panic(err)
+	curvas := hipoteses
+	if len(b.A) > 0 {
+		curvas = append([]Curva{{Nome: "alvo f", F: b.Avalia}}, hipoteses...)
+	}
+	for i, c := range curvas {
+		l, err := plotter.NewLine(curvaToPlotter(c.F, gr.amostras()))
+		if err != nil {
+			return err
+		}
+		l.LineStyle.Width = vg.Points(1.5)
+		l.LineStyle.Color = plotutil.Color(i)
+		l.LineStyle.Dashes = plotutil.Dashes(i)
+		p.Add(l)
+		p.Legend.Add(c.Nome, l)
 	}
-	s.GlyphStyle.Color = color.RGBA{R: 0, B: 128, A: 255}
-	p.Add(s)
 
-	// Make a scatter plotter and set its style.
-	s, err = plotter.NewScatter(predictedToPlotter(b, yP10))
-	if err != nil {
-		panic(err)
+	if gr.YMin != gr.YMax {
+		p.Y.Min, p.Y.Max = gr.YMin, gr.YMax
 	}
-	s.GlyphStyle.Color = color.RGBA{R: 0, B: 0, G: 255, A: 255}
-	p.Add(s)
+	return p.Save(gr.tamanho(gr.Largura), gr.tamanho(gr.Altura), path)
+}
 
-	// Save the plot to a PNG file.
-	if err := p.Save(4*vg.Inch, 4*vg.Inch, "points.png"); err != nil {
-		panic(err)
+//formatoSuportado confere a extensão de path antes de desenhar
+func formatoSuportado(path string) error {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if !formatos[ext] {
+		return fmt.Errorf("formato de figura %q não suportado; use png, svg ou pdf", ext)
 	}
+	return nil
+}
+
+func (gr Grafico) amostras() int {
+	if gr.Amostras > 1 {
+		return gr.Amostras
+	}
+	return 200
+}
+
+func (gr Grafico) tamanho(l vg.Length) vg.Length {
+	if l > 0 {
+		return l
+	}
+	return 4 * vg.Inch
 }
 
 func baseToPlotter(b Base) plotter.XYs {
@@ -60,11 +131,12 @@ func baseToPlotter(b Base) plotter.XYs {
 	return pts
 }
 
-func predictedToPlotter(b Base, y num.Matrix) plotter.XYs {
-	pts := make(plotter.XYs, len(b.X))
+//curvaToPlotter avalia f em n pontos igualmente espaçados de -1 a 1
+func curvaToPlotter(f func(x float64) float64, n int) plotter.XYs {
+	pts := make(plotter.XYs, n)
 	for i := range pts {
-		pts[i].X = b.X[i]
-		pts[i].Y = y.Data[i][0]
+		pts[i].X = -1 + 2*float64(i)/float64(n-1)
+		pts[i].Y = f(pts[i].X)
 	}
 	return pts
 }
@@ -0,0 +1,126 @@
+package lfdoverfitting
+
+import (
+	"math"
+
+	"github.com/rgarrot/lfdoverfitting/legendre"
+)
+
+//Poly polinômio na base de monômios: p[0]x^0 + p[1]x^1 + ... + p[n]x^n.
+//As operações devolvem polinômios novos e não alteram os operandos.
+type Poly []float64
+
+//Avalia p(x) pelo método de Horner
+func (p Poly) Avalia(x float64) float64 {
+	result := 0.0
+	for i := len(p) - 1; i >= 0; i-- {
+		result = result*x + p[i]
+	}
+	return result
+}
+
+//Grau índice do maior coeficiente não nulo; -1 para o polinômio nulo
+func (p Poly) Grau() int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+//Apara remove os coeficientes finais com |p[i]| <= tol; o polinômio nulo vira Poly{}
+func (p Poly) Apara(tol float64) Poly {
+	n := len(p)
+	for n > 0 && math.Abs(p[n-1]) <= tol {
+		n--
+	}
+	return append(Poly{}, p[:n]...)
+}
+
+//Soma p(x) + q(x)
+func (p Poly) Soma(q Poly) Poly {
+	if len(q) > len(p) {
+		p, q = q, p
+	}
+	r := append(Poly{}, p...)
+	for i, c := range q {
+		r[i] += c
+	}
+	return r
+}
+
+//Subtrai p(x) - q(x)
+func (p Poly) Subtrai(q Poly) Poly {
+	return p.Soma(q.Escala(-1))
+}
+
+//Escala c * p(x)
+func (p Poly) Escala(c float64) Poly {
+	r := make(Poly, len(p))
+	for i, pi := range p {
+		r[i] = c * pi
+	}
+	return r
+}
+
+//Multiplica p(x) * q(x)
+func (p Poly) Multiplica(q Poly) Poly {
+	if len(p) == 0 || len(q) == 0 {
+		return Poly{}
+	}
+	r := make(Poly, len(p)+len(q)-1)
+	for i := 0; i < len(p); i++ {
+		for j := 0; j < len(q); j++ {
+			r[i+j] += p[i] * q[j]
+		}
+	}
+	return r
+}
+
+//Derivada p'(x)
+func (p Poly) Derivada() Poly {
+	if len(p) <= 1 {
+		return Poly{}
+	}
+	r := make(Poly, len(p)-1)
+	for i := 1; i < len(p); i++ {
+		r[i-1] = float64(i) * p[i]
+	}
+	return r
+}
+
+//Primitiva P(x) com P' = p e P(0) = 0
+func (p Poly) Primitiva() Poly {
+	r := make(Poly, len(p)+1)
+	for i, pi := range p {
+		r[i+1] = pi / float64(i+1)
+	}
+	return r
+}
+
+//Integral{a^b}( p(x) )
+func (p Poly) Integral(a float64, b float64) float64 {
+	P := p.Primitiva()
+	return P.Avalia(b) - P.Avalia(a)
+}
+
+//Compoe p(q(x)), por Horner sobre polinômios
+func (p Poly) Compoe(q Poly) Poly {
+	r := Poly{}
+	for i := len(p) - 1; i >= 0; i-- {
+		r = r.Multiplica(q).Soma(Poly{p[i]})
+	}
+	return r
+}
+
+//Legendre coeficientes a de p = sum_k ( a[k] * Legendre_k(x) ), pela conversão de legendre.DeMonomios
+func (p Poly) Legendre() []float64 {
+	return legendre.DeMonomios(p)
+}
+
+//PolyLegendre converte sum_k ( a[k] * Legendre_k(x) ) em coeficientes de monômios com a LegendreBasis
+//compartilhada; falha para graus acima de GrauMaximoLegendre
+func PolyLegendre(a []float64) (Poly, error) {
+	return basePadrao.Monomial(a)
+}
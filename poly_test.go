@@ -0,0 +1,44 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoly(t *testing.T) {
+	p := Poly{1, -2, 0, 3}  //1 - 2x + 3x^3
+	q := Poly{0.5, 1, 0, 0} //0.5 + x
+	x := 0.7
+	perto := func(nome string, got, want float64) {
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: got %v; want %v", nome, got, want)
+		}
+	}
+
+	perto("avalia", p.Avalia(x), 1-2*x+3*x*x*x)
+	perto("soma", p.Soma(q).Avalia(x), p.Avalia(x)+q.Avalia(x))
+	perto("subtrai", p.Subtrai(q).Avalia(x), p.Avalia(x)-q.Avalia(x))
+	perto("escala", p.Escala(-3).Avalia(x), -3*p.Avalia(x))
+	perto("multiplica", p.Multiplica(q).Avalia(x), p.Avalia(x)*q.Avalia(x))
+	perto("compoe", p.Compoe(q).Avalia(x), p.Avalia(q.Avalia(x)))
+	perto("derivada", p.Derivada().Avalia(x), -2+9*x*x)
+	perto("primitiva", p.Primitiva().Derivada().Avalia(x), p.Avalia(x))
+	perto("integral", p.Integral(-0.5, 2), (2-4+12)-(-0.5-0.25+3*0.0625/4))
+
+	if g := q.Grau(); g != 1 {
+		t.Errorf("grau: got %d; want 1", g)
+	}
+	if a := q.Apara(0); len(a) != 2 {
+		t.Errorf("apara: got %v; want 2 coeficientes", a)
+	}
+
+	a := p.Legendre()
+	perto("legendre", AvaliaLegendre(a, x), p.Avalia(x))
+	r, err := PolyLegendre(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range p {
+		perto("ida e volta", r[i], p[i])
+	}
+}
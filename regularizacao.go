@@ -0,0 +1,106 @@
+package lfdoverfitting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+//Regularizacao weight decay Omega(w) = sum_q gamma_q w_q² sobre os coeficientes de legendre w, com peso Lambda.
+//Penalidade "ridge" (padrão) usa gamma_q = 1; "legendre" usa gamma_q = 2/(2q+1),
+//de modo que Omega(w) = Integral{-1^1}( g(x)² ) mede a energia da hipótese em vez do tamanho dos coeficientes.
+type Regularizacao struct {
+	Lambda     float64 `json:"lambda,omitempty"`
+	Penalidade string  `json:"penalidade,omitempty"`
+}
+
+//penalidades nomes aceitos em Regularizacao.Penalidade
+const (
+	PenalidadeRidge    = "ridge"
+	PenalidadeLegendre = "legendre"
+)
+
+//pesos gamma_0..gamma_n da penalidade
+func (r Regularizacao) pesos(n int) ([]float64, error) {
+	if r.Lambda < 0 || math.IsNaN(r.Lambda) {
+		return nil, fmt.Errorf("regularização: lambda = %v deve ser não negativo", r.Lambda)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("regularização: grau %d negativo", n)
+	}
+	gamma := make([]float64, n+1)
+	for q := range gamma {
+		switch r.Penalidade {
+		case "", PenalidadeRidge:
+			gamma[q] = 1
+		case PenalidadeLegendre:
+			gamma[q] = 2 / float64(2*q+1)
+		default:
+			return nil, fmt.Errorf("regularização: penalidade %q desconhecida; use %q ou %q", r.Penalidade, PenalidadeRidge, PenalidadeLegendre)
+		}
+	}
+	return gamma, nil
+}
+
+//String descrição curta, ex.: "ridge lambda=0.1"; vazia sem regularização
+func (r Regularizacao) String() string {
+	if r.Lambda == 0 {
+		return ""
+	}
+	p := r.Penalidade
+	if p == "" {
+		p = PenalidadeRidge
+	}
+	return p + " lambda=" + strconv.FormatFloat(r.Lambda, 'g', -1, 64)
+}
+
+//PontoLambda erros e complexidade efetiva do ajuste regularizado para um valor de lambda
+type PontoLambda struct {
+	Lambda   float64
+	Ein      float64
+	Eout     float64 //EoutEsperado em relação ao alvo da base, na escala de Ein; NaN sem alvo
+	Efetivos float64 //número efetivo de parâmetros
+}
+
+//CurvaLambda resultados do ajuste regularizado ao longo de uma grade de lambdas
+type CurvaLambda []PontoLambda
+
+//VarreLambda ajusta o grau grau com a penalidade dada para cada lambda da grade
+func VarreLambda(b Base, grau int, penalidade string, lambdas []float64) (CurvaLambda, error) {
+	if len(lambdas) == 0 {
+		return nil, errors.New("regularização: grade de lambdas vazia")
+	}
+	c := make(CurvaLambda, len(lambdas))
+	for i, lambda := range lambdas {
+		a, err := AjustaRegularizado(b, grau, Regularizacao{Lambda: lambda, Penalidade: penalidade})
+		if err != nil {
+			return nil, err
+		}
+		c[i] = PontoLambda{Lambda: lambda, Ein: a.Residuo * a.Residuo / float64(len(b.X)), Eout: EoutEsperado(b, a.Legendre), Efetivos: a.Efetivos}
+	}
+	return c, nil
+}
+
+//EscreveCSV grava uma linha por lambda com as colunas lambda,ein,eout,efetivos
+func (c CurvaLambda) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"lambda", "ein", "eout", "efetivos"}); err != nil {
+		return err
+	}
+	for _, p := range c {
+		linha := []string{
+			strconv.FormatFloat(p.Lambda, 'g', -1, 64),
+			strconv.FormatFloat(p.Ein, 'g', -1, 64),
+			strconv.FormatFloat(p.Eout, 'g', -1, 64),
+			strconv.FormatFloat(p.Efetivos, 'g', -1, 64),
+		}
+		if err := cw.Write(linha); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,171 @@
+package lfdoverfitting
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+//Ruido modelo do ruído estocástico somado ao alvo: y_n = f(x_n) + e(x_n).
+//Todos os modelos são parametrizados pelo desvio padrão, de modo que Energia() = E[e²] é comparável entre eles.
+type Ruido interface {
+	Amostra(rng *rand.Rand, x float64) float64 //sorteia o ruído no ponto x usando o gerador rng
+	Energia() float64                          //energia do ruído estocástico E_x[E[e²]] para x uniforme em [-1;1]
+	Nome() string                              //identificação do modelo
+	Parametros() map[string]float64            //parâmetros do modelo
+}
+
+//RuidoGaussiano e ~ N(0, sigma²)
+type RuidoGaussiano struct {
+	Sigma float64
+}
+
+func (r RuidoGaussiano) Amostra(rng *rand.Rand, x float64) float64 {
+	return r.Sigma * rng.NormFloat64()
+}
+func (r RuidoGaussiano) Energia() float64 { return r.Sigma * r.Sigma }
+func (r RuidoGaussiano) Nome() string     { return "gaussiano" }
+func (r RuidoGaussiano) Parametros() map[string]float64 {
+	return map[string]float64{"sigma": r.Sigma}
+}
+
+//RuidoLaplace distribuição de Laplace com escala b = sigma/sqrt(2)
+type RuidoLaplace struct {
+	Sigma float64
+}
+
+func (r RuidoLaplace) Amostra(rng *rand.Rand, x float64) float64 {
+	e := r.Sigma / math.Sqrt2 * rng.ExpFloat64()
+	if rng.Float64() < 0.5 {
+		return -e
+	}
+	return e
+}
+func (r RuidoLaplace) Energia() float64 { return r.Sigma * r.Sigma }
+func (r RuidoLaplace) Nome() string     { return "laplace" }
+func (r RuidoLaplace) Parametros() map[string]float64 {
+	return map[string]float64{"sigma": r.Sigma}
+}
+
+//RuidoStudentT t de Student com Nu graus de liberdade (Nu > 2), reescalada para desvio padrão sigma
+type RuidoStudentT struct {
+	Sigma float64
+	Nu    int
+}
+
+func (r RuidoStudentT) Amostra(rng *rand.Rand, x float64) float64 {
+	//t = z / sqrt(v/nu), v ~ qui-quadrado com nu graus de liberdade
+	v := 0.0
+	for i := 0; i < r.Nu; i++ {
+		z := rng.NormFloat64()
+		v += z * z
+	}
+	t := rng.NormFloat64() / math.Sqrt(v/float64(r.Nu))
+
+	//variância da t é nu/(nu-2)
+	return r.Sigma * math.Sqrt(float64(r.Nu-2)/float64(r.Nu)) * t
+}
+func (r RuidoStudentT) Energia() float64 { return r.Sigma * r.Sigma }
+func (r RuidoStudentT) Nome() string     { return "student-t" }
+func (r RuidoStudentT) Parametros() map[string]float64 {
+	return map[string]float64{"sigma": r.Sigma, "nu": float64(r.Nu)}
+}
+
+//RuidoUniforme e ~ U[-a;a] com a = sigma*sqrt(3)
+type RuidoUniforme struct {
+	Sigma float64
+}
+
+func (r RuidoUniforme) Amostra(rng *rand.Rand, x float64) float64 {
+	return r.Sigma * math.Sqrt(3) * (-1.0 + 2.0*rng.Float64())
+}
+func (r RuidoUniforme) Energia() float64 { return r.Sigma * r.Sigma }
+func (r RuidoUniforme) Nome() string     { return "uniforme" }
+func (r RuidoUniforme) Parametros() map[string]float64 {
+	return map[string]float64{"sigma": r.Sigma}
+}
+
+//RuidoHeterocedastico gaussiano com desvio dependente de x: sigma(x) = Sigma0 + Sigma1*|x|
+type RuidoHeterocedastico struct {
+	Sigma0 float64
+	Sigma1 float64
+}
+
+func (r RuidoHeterocedastico) Amostra(rng *rand.Rand, x float64) float64 {
+	return (r.Sigma0 + r.Sigma1*math.Abs(x)) * rng.NormFloat64()
+}
+
+//Energia E_x[sigma(x)²] = sigma0² + sigma0*sigma1 + sigma1²/3 para x uniforme em [-1;1]
+func (r RuidoHeterocedastico) Energia() float64 {
+	return r.Sigma0*r.Sigma0 + r.Sigma0*r.Sigma1 + r.Sigma1*r.Sigma1/3.0
+}
+func (r RuidoHeterocedastico) Nome() string { return "heterocedastico" }
+func (r RuidoHeterocedastico) Parametros() map[string]float64 {
+	return map[string]float64{"sigma0": r.Sigma0, "sigma1": r.Sigma1}
+}
+
+//NovoRuido reconstrói um modelo a partir do nome e dos parâmetros devolvidos por Nome() e Parametros()
+func NovoRuido(nome string, p map[string]float64) (Ruido, error) {
+	switch nome {
+	case "gaussiano":
+		return RuidoGaussiano{Sigma: p["sigma"]}, nil
+	case "laplace":
+		return RuidoLaplace{Sigma: p["sigma"]}, nil
+	case "student-t":
+		nu, ok := p["nu"]
+		if !ok {
+			return nil, fmt.Errorf("ruído student-t: falta o parâmetro nu")
+		}
+		if err := validaNu(nu); err != nil {
+			return nil, err
+		}
+		return RuidoStudentT{Sigma: p["sigma"], Nu: int(nu)}, nil
+	case "uniforme":
+		return RuidoUniforme{Sigma: p["sigma"]}, nil
+	case "heterocedastico":
+		return RuidoHeterocedastico{Sigma0: p["sigma0"], Sigma1: p["sigma1"]}, nil
+	}
+	return nil, fmt.Errorf("modelo de ruído desconhecido %q", nome)
+}
+
+//FabricaRuido devolve o construtor do modelo de ruído de nome dado para cada sigma da grade.
+//Parâmetros extras: nu (student-t, padrão 5) e sigma1 (heterocedastico, sigma(x) = sigma + sigma1*|x|).
+func FabricaRuido(nome string, parametros map[string]float64) (func(sigma float64) Ruido, error) {
+	switch nome {
+	case "", "gaussiano":
+		return ruidoGaussiano, nil
+	case "laplace":
+		return func(sigma float64) Ruido { return RuidoLaplace{Sigma: sigma} }, nil
+	case "student-t":
+		nu, ok := parametros["nu"]
+		if !ok {
+			nu = 5
+		}
+		if err := validaNu(nu); err != nil {
+			return nil, err
+		}
+		return func(sigma float64) Ruido { return RuidoStudentT{Sigma: sigma, Nu: int(nu)} }, nil
+	case "uniforme":
+		return func(sigma float64) Ruido { return RuidoUniforme{Sigma: sigma} }, nil
+	case "heterocedastico":
+		sigma1 := parametros["sigma1"]
+		if sigma1 < 0 {
+			return nil, fmt.Errorf("ruído heterocedastico: sigma1 = %v deve ser não negativo", sigma1)
+		}
+		return func(sigma float64) Ruido { return RuidoHeterocedastico{Sigma0: sigma, Sigma1: sigma1} }, nil
+	}
+	return nil, fmt.Errorf("modelo de ruído desconhecido %q", nome)
+}
+
+//validaNu graus de liberdade aceitos pela t de Student: a variância nu/(nu-2) só é finita para nu > 2
+func validaNu(nu float64) error {
+	if nu <= 2 || nu != math.Trunc(nu) {
+		return fmt.Errorf("ruído student-t: nu = %v deve ser inteiro maior que 2", nu)
+	}
+	return nil
+}
+
+//ruidoGaussiano construtor padrão usado pelo sweep para cada sigma da grade
+func ruidoGaussiano(sigma float64) Ruido {
+	return RuidoGaussiano{Sigma: sigma}
+}
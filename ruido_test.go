@@ -0,0 +1,50 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRuidoEnergia(t *testing.T) {
+	//Energia() = E_x[E[e²]]: confere com a média amostral de e² para x uniforme em [-1;1]
+	const amostras = 200000
+	modelos := []Ruido{
+		RuidoGaussiano{Sigma: 0.7},
+		RuidoLaplace{Sigma: 0.7},
+		RuidoStudentT{Sigma: 0.7, Nu: 6},
+		RuidoUniforme{Sigma: 0.7},
+		RuidoHeterocedastico{Sigma0: 0.3, Sigma1: 0.8},
+	}
+	for i, r := range modelos {
+		rng := NovoRNG(int64(i + 1))
+		soma, soma2 := 0.0, 0.0
+		for n := 0; n < amostras; n++ {
+			e := r.Amostra(rng, -1+2*rng.Float64())
+			soma += e
+			soma2 += e * e
+		}
+		media, energia := soma/amostras, soma2/amostras
+		if math.Abs(energia-r.Energia()) > 0.02*r.Energia() || math.Abs(media) > 0.01 {
+			t.Errorf("%s: média %v, energia amostral %v; want 0 e %v", r.Nome(), media, energia, r.Energia())
+		}
+
+		//Nome e Parametros reconstroem o mesmo modelo
+		if s, err := NovoRuido(r.Nome(), r.Parametros()); err != nil || s != r {
+			t.Errorf("%s: NovoRuido = %v, %v; want %v", r.Nome(), s, err, r)
+		}
+	}
+}
+
+func TestNovoRuidoStudentT(t *testing.T) {
+	for _, p := range []map[string]float64{{"sigma": 1}, {"sigma": 1, "nu": 2}, {"sigma": 1, "nu": 4.5}} {
+		if r, err := NovoRuido("student-t", p); err == nil {
+			t.Errorf("%v: got %v; want erro", p, r)
+		}
+	}
+	if _, err := FabricaRuido("student-t", map[string]float64{"nu": 1}); err == nil {
+		t.Error("FabricaRuido aceitou nu = 1")
+	}
+	if _, err := NovoRuido("cauchy", nil); err == nil {
+		t.Error("modelo desconhecido aceito")
+	}
+}
@@ -0,0 +1,72 @@
+package lfdoverfitting
+
+import (
+	"errors"
+	"fmt"
+)
+
+//RuidoDeterministico parte do alvo que a melhor hipótese de grau Q não consegue capturar.
+//Com x uniforme em [-1;1] os polinômios de legendre são ortogonais, E[L_q²] = 1/(2q+1),
+//e a melhor hipótese g* de H_Q é a truncagem da série de legendre do alvo no grau Q.
+//As energias são esperanças sobre x, na mesma escala de Ruido.Energia() = sigma².
+type RuidoDeterministico struct {
+	Grau     int       //Q, grau do conjunto de hipóteses
+	Melhor   Alvo      //g*: a_0..a_Q do alvo
+	Espectro []float64 //energia E[(a_q L_q)²] = a_q²/(2q+1) de cada grau q do alvo
+	Energia  float64   //E[(f - g*)²] = sum_{q>Q} a_q²/(2q+1)
+}
+
+//RuidoDeterministico calcula g*, o espectro e a energia do ruído determinístico do alvo para hipóteses de grau grau.
+//Se grau >= Qf o alvo está em H_Q e a energia é zero.
+func (a Alvo) RuidoDeterministico(grau int) (RuidoDeterministico, error) {
+	if grau < 0 {
+		return RuidoDeterministico{}, fmt.Errorf("ruído determinístico: grau %d negativo", grau)
+	}
+	if len(a.A) == 0 {
+		return RuidoDeterministico{}, errors.New("ruído determinístico: alvo sem coeficientes de legendre")
+	}
+
+	rd := RuidoDeterministico{Grau: grau, Espectro: a.Espectro()}
+	q := grau + 1
+	if q > len(a.A) {
+		q = len(a.A)
+	}
+	rd.Melhor = NovoAlvo(append([]float64{}, a.A[:q]...))
+	for _, e := range rd.Espectro[q:] {
+		rd.Energia += e
+	}
+	return rd, nil
+}
+
+//Espectro energia a_q²/(2q+1) de cada termo de legendre do alvo; a soma é E[f²]
+func (a Alvo) Espectro() []float64 {
+	e := make([]float64, len(a.A))
+	for q, aq := range a.A {
+		e[q] = aq * aq / float64(2*q+1)
+	}
+	return e
+}
+
+//Energia E[f²] do alvo; vale 1 em média sobre os sorteios de GeraAlvo
+func (a Alvo) Energia() float64 {
+	soma := 0.0
+	for _, e := range a.Espectro() {
+		soma += e
+	}
+	return soma
+}
+
+//EnergiaDeterministicaEsperada média da energia do ruído determinístico sobre os alvos de grau qf sorteados por GeraAlvo.
+//Como E[a_q²] = 1/C, com C = sum_{q=0}^{qf} 1/(2q+1), o resultado é sum_{q>grau} (1/(2q+1)) / C.
+//Permite ler cada célula (Qf, N, sigma) de um sweep como ruído determinístico contra estocástico (sigma²).
+func EnergiaDeterministicaEsperada(qf int, grau int) float64 {
+	c, soma := 0.0, 0.0
+	for q := 0; q <= qf; q++ {
+		e := 1.0 / float64(2*q+1)
+		c += e
+		if q > grau {
+			soma += e
+		}
+	}
+	return soma / c
+}
@@ -0,0 +1,29 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRuidoDeterministico(t *testing.T) {
+	alvo := GeraAlvo(NovoRNG(11), 10)
+	for _, grau := range []int{2, 5, 10, 12} {
+		rd, err := alvo.RuidoDeterministico(grau)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := alvo.Monomial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := rd.Melhor.Monomial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		//Eout integra sobre [-1;1]; a energia é a esperança com x uniforme
+		want := Eout(f, g) / 2
+		if math.Abs(rd.Energia-want) > 1e-9 {
+			t.Errorf("grau %d: got energia %v; want %v", grau, rd.Energia, want)
+		}
+	}
+}
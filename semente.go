@@ -0,0 +1,27 @@
+package lfdoverfitting
+
+import "math/rand"
+
+//NovoRNG cria um gerador de números aleatórios independente a partir de uma semente
+func NovoRNG(semente int64) *rand.Rand {
+	return rand.New(rand.NewSource(semente))
+}
+
+//DerivaSemente deriva de forma determinística uma semente filha da semente mãe e de uma sequência de índices.
+//Cada índice é misturado com o finalizador do splitmix64, de modo que (semente, célula, repetição)
+//gera sempre o mesmo fluxo e fluxos de índices vizinhos são independentes.
+func DerivaSemente(semente int64, indices ...int) int64 {
+	z := uint64(semente)
+	for _, i := range indices {
+		z = splitmix64(z ^ splitmix64(uint64(i)))
+	}
+	return int64(z)
+}
+
+//splitmix64 um passo do gerador splitmix64 (Steele, Lea e Flood)
+func splitmix64(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,354 @@
+package lfdoverfitting
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+//Grade de parâmetros (Qf, N, sigma) varrida pelo experimento
+type Grade struct {
+	Qf    []int
+	N     []int
+	Sigma []float64
+}
+
+//Celula da grade: complexidade do alvo, tamanho da base e nível de ruído
+type Celula struct {
+	Qf    int     `json:"qf"`
+	N     int     `json:"n"`
+	Sigma float64 `json:"sigma"`
+}
+
+//Resultado agregado da medida de overfit em uma célula da grade
+type Resultado struct {
+	Celula
+	Media      float64 `json:"media"`       //média de Eout(f, gComplexo) - Eout(f, gSimples)
+	ErroPadrao float64 `json:"erro_padrao"` //erro padrão da média
+	Execucoes  int     `json:"execucoes"`   //número de repetições agregadas
+}
+
+//MarshalJSON grava Media e ErroPadrao não finitos como as strings "NaN", "+Inf" e "-Inf",
+//que encoding/json recusa como números; com uma única repetição o erro padrão é NaN
+func (r Resultado) MarshalJSON() ([]byte, error) {
+	type semMetodos Resultado
+	return json.Marshal(struct {
+		semMetodos
+		Media      real `json:"media"`
+		ErroPadrao real `json:"erro_padrao"`
+	}{semMetodos(r), real(r.Media), real(r.ErroPadrao)})
+}
+
+//UnmarshalJSON lê Media e ErroPadrao como números ou como as strings de MarshalJSON
+func (r *Resultado) UnmarshalJSON(dados []byte) error {
+	type semMetodos Resultado
+	var aux struct {
+		semMetodos
+		Media      real `json:"media"`
+		ErroPadrao real `json:"erro_padrao"`
+	}
+	if err := json.Unmarshal(dados, &aux); err != nil {
+		return err
+	}
+	*r = Resultado(aux.semMetodos)
+	r.Media, r.ErroPadrao = float64(aux.Media), float64(aux.ErroPadrao)
+	return nil
+}
+
+//real float64 que passa por JSON mesmo quando não é finito
+type real float64
+
+func (v real) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return json.Marshal(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	return json.Marshal(f)
+}
+
+func (v *real) UnmarshalJSON(dados []byte) error {
+	var f float64
+	if len(dados) > 0 && dados[0] == '"' {
+		var s string
+		if err := json.Unmarshal(dados, &s); err != nil {
+			return err
+		}
+		var err error
+		if f, err = strconv.ParseFloat(s, 64); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(dados, &f); err != nil {
+		return err
+	}
+	*v = real(f)
+	return nil
+}
+
+//Tabela de resultados do sweep, uma linha por célula
+type Tabela []Resultado
+
+//Sweep experimento de Monte Carlo do Exercício 4.2 / Problema 4.4.
+//Para cada célula da grade gera Repeticoes bases, ajusta as duas hipóteses e agrega a medida de overfit.
+type Sweep struct {
+	Grade        Grade
+	Repeticoes   int
+	GrauSimples  int //grau da hipótese simples (H2)
+	GrauComplexo int //grau da hipótese complexa (H10)
+
+	Regularizacao Regularizacao //weight decay aplicado à hipótese complexa; Lambda = 0 usa o ajuste puro
+
+	NovoRuido func(sigma float64) Ruido //modelo de ruído para cada sigma da grade; nil usa o gaussiano
+
+	Semente int64 //semente mãe; cada (célula, repetição) recebe um fluxo derivado dela
+
+	Trabalhadores int //número de goroutines do pool; 0 usa runtime.NumCPU()
+
+	Concluidas map[int]Resultado              //células já calculadas (retomada), indexadas pela posição em Grade.Celulas()
+	AoConcluir func(i int, r Resultado) error //chamada quando a célula i termina; as chamadas são serializadas, uma célula por vez
+}
+
+//NovoSweep cria um sweep comparando H2 e H10 com ruído gaussiano, como no livro
+func NovoSweep(g Grade, repeticoes int, semente int64) Sweep {
+	return Sweep{Grade: g, Repeticoes: repeticoes, GrauSimples: 2, GrauComplexo: 10, NovoRuido: ruidoGaussiano, Semente: semente}
+}
+
+//Celulas lista as combinações da grade na ordem Qf, N, sigma
+func (g Grade) Celulas() []Celula {
+	var cs []Celula
+	for _, qf := range g.Qf {
+		for _, n := range g.N {
+			for _, sigma := range g.Sigma {
+				cs = append(cs, Celula{Qf: qf, N: n, Sigma: sigma})
+			}
+		}
+	}
+	return cs
+}
+
+//tarefa uma repetição j da célula i
+type tarefa struct {
+	i int
+	j int
+}
+
+//Executa roda todas as células da grade num pool de Trabalhadores goroutines.
+//Cada repetição usa o fluxo derivado de (Semente, i, j) e grava seu valor numa posição fixa,
+//portanto a tabela é idêntica à de uma execução serial, qualquer que seja a ordem de escalonamento.
+//Os ajustes não compartilham estado mutável, o que é seguro entre goroutines.
+func (s Sweep) Executa() (Tabela, error) {
+	return s.ExecutaContexto(context.Background())
+}
+
+//ExecutaContexto como Executa, mas para de distribuir repetições quando ctx é cancelado.
+//Células em Concluidas não são recalculadas; cada célula nova é entregue a AoConcluir assim que termina.
+//Se ctx for cancelado devolve ctx.Err() depois que as repetições em andamento terminam.
+func (s Sweep) ExecutaContexto(ctx context.Context) (Tabela, error) {
+	if s.Repeticoes < 1 {
+		return nil, fmt.Errorf("sweep: repetições = %d deve ser pelo menos 1", s.Repeticoes)
+	}
+	ctx, cancela := context.WithCancel(ctx)
+	defer cancela()
+
+	celulas := s.Grade.Celulas()
+	t := make(Tabela, len(celulas))
+	valores := make([][]float64, len(celulas))
+	restantes := make([]int, len(celulas))
+	for i := range celulas {
+		if r, ok := s.Concluidas[i]; ok {
+			t[i] = r
+			continue
+		}
+		valores[i] = make([]float64, s.Repeticoes)
+		restantes[i] = s.Repeticoes
+	}
+
+	var mu sync.Mutex
+	var muConclui sync.Mutex //serializa as chamadas de AoConcluir, feitas pelos trabalhadores
+	var erro error
+	falha := func(err error) {
+		mu.Lock()
+		if erro == nil {
+			erro = err
+			cancela()
+		}
+		mu.Unlock()
+	}
+
+	//conclui agrega a célula i quando sua última repetição termina
+	conclui := func(i int) {
+		mu.Lock()
+		restantes[i]--
+		if restantes[i] > 0 {
+			mu.Unlock()
+			return
+		}
+		media, erroPadrao := agrega(valores[i])
+		t[i] = Resultado{Celula: celulas[i], Media: media, ErroPadrao: erroPadrao, Execucoes: len(valores[i])}
+		valores[i] = nil
+		mu.Unlock()
+
+		if s.AoConcluir != nil {
+			muConclui.Lock()
+			err := s.AoConcluir(i, t[i])
+			muConclui.Unlock()
+			if err != nil {
+				falha(err)
+			}
+		}
+	}
+
+	tarefas := make(chan tarefa)
+	var wg sync.WaitGroup
+	for w := 0; w < s.trabalhadores(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := NovoRNG(0) //gerador do trabalhador, ressemeado a cada tarefa
+			for tr := range tarefas {
+				rng.Seed(DerivaSemente(s.Semente, tr.i, tr.j))
+				v, err := s.Overfit(s.base(rng, celulas[tr.i]))
+				if err != nil {
+					falha(fmt.Errorf("célula %+v, repetição %d: %v", celulas[tr.i], tr.j, err))
+					continue
+				}
+				valores[tr.i][tr.j] = v
+				conclui(tr.i)
+			}
+		}()
+	}
+
+distribui:
+	for i := range celulas {
+		if valores[i] == nil {
+			continue
+		}
+		for j := 0; j < s.Repeticoes; j++ {
+			select {
+			case tarefas <- tarefa{i: i, j: j}:
+			case <-ctx.Done():
+				break distribui
+			}
+		}
+	}
+	close(tarefas)
+	wg.Wait()
+
+	if erro != nil {
+		return nil, erro
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+//trabalhadores número efetivo de goroutines do pool
+func (s Sweep) trabalhadores() int {
+	if s.Trabalhadores > 0 {
+		return s.Trabalhadores
+	}
+	return runtime.NumCPU()
+}
+
+//assinatura identifica a configuração do sweep; a mesma assinatura gera os mesmos resultados
+func (s Sweep) assinatura() string {
+	ruido := s.ruido(1)
+	a := fmt.Sprintf("grade=%v repeticoes=%d graus=%d,%d semente=%d ruido=%s%v",
+		s.Grade, s.Repeticoes, s.GrauSimples, s.GrauComplexo, s.Semente, ruido.Nome(), ruido.Parametros())
+	if s.Regularizacao.Lambda != 0 {
+		a += " regularizacao=" + s.Regularizacao.String()
+	}
+	return a
+}
+
+//RegeraBase reproduz exatamente a base usada na repetição j da célula i
+func (s Sweep) RegeraBase(i int, j int) Base {
+	rng := NovoRNG(DerivaSemente(s.Semente, i, j))
+	return s.base(rng, s.Grade.Celulas()[i])
+}
+
+//base gera uma base para a célula c a partir do gerador rng
+func (s Sweep) base(rng *rand.Rand, c Celula) Base {
+	return GeraBase(rng, c.Qf, c.N, s.ruido(c.Sigma))
+}
+
+//ruido modelo de ruído para o nível sigma, gaussiano quando NovoRuido é nil
+func (s Sweep) ruido(sigma float64) Ruido {
+	if s.NovoRuido == nil {
+		return ruidoGaussiano(sigma)
+	}
+	return s.NovoRuido(sigma)
+}
+
+//Overfit ajusta as duas hipóteses na base e calcula Eout(f, gComplexo) - Eout(f, gSimples).
+//A hipótese complexa recebe o weight decay de s.Regularizacao.
+func (s Sweep) Overfit(b Base) (float64, error) {
+	gs, err := AjustaLegendre(b, s.GrauSimples)
+	if err != nil {
+		return math.NaN(), err
+	}
+	gc, err := AjustaRegularizado(b, s.GrauComplexo, s.Regularizacao)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return EoutLegendre(b.A, gc.Legendre) - EoutLegendre(b.A, gs.Legendre), nil
+}
+
+//agrega calcula a média e o erro padrão da média (desvio amostral / sqrt(n))
+func agrega(valores []float64) (media float64, erroPadrao float64) {
+	n := float64(len(valores))
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	for _, v := range valores {
+		media += v
+	}
+	media /= n
+	if n < 2 {
+		return media, math.NaN()
+	}
+
+	variancia := 0.0
+	for _, v := range valores {
+		variancia += (v - media) * (v - media)
+	}
+	variancia /= n - 1
+	return media, math.Sqrt(variancia / n)
+}
+
+//cabecalhoCSV colunas de uma linha da tabela
+var cabecalhoCSV = []string{"qf", "n", "sigma", "media", "erro_padrao", "execucoes"}
+
+//linhaCSV formata um resultado na ordem de cabecalhoCSV, sem perda de precisão
+func linhaCSV(r Resultado) []string {
+	return []string{
+		strconv.Itoa(r.Qf),
+		strconv.Itoa(r.N),
+		strconv.FormatFloat(r.Sigma, 'g', -1, 64),
+		strconv.FormatFloat(r.Media, 'g', -1, 64),
+		strconv.FormatFloat(r.ErroPadrao, 'g', -1, 64),
+		strconv.Itoa(r.Execucoes),
+	}
+}
+
+//EscreveCSV escreve a tabela com cabeçalho qf,n,sigma,media,erro_padrao,execucoes
+func (t Tabela) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(cabecalhoCSV); err != nil {
+		return err
+	}
+	for _, r := range t {
+		if err := cw.Write(linhaCSV(r)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
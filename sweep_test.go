@@ -0,0 +1,242 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSweepParaleloIgualSerial(t *testing.T) {
+	g := Grade{Qf: []int{3, 8}, N: []int{20, 35}, Sigma: []float64{0.0, 0.5}}
+
+	serial := NovoSweep(g, 20, 42)
+	serial.Trabalhadores = 1
+	paralelo := NovoSweep(g, 20, 42)
+	paralelo.Trabalhadores = 4
+
+	ts, err := serial.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp, err := paralelo.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ts) != len(tp) {
+		t.Fatalf("got %d células; want %d", len(tp), len(ts))
+	}
+	for i := range ts {
+		if ts[i] != tp[i] {
+			t.Errorf("#%d got %+v; want %+v", i, tp[i], ts[i])
+		}
+	}
+}
+
+func TestRegeraBase(t *testing.T) {
+	g := Grade{Qf: []int{5}, N: []int{20}, Sigma: []float64{0.3}}
+	s := NovoSweep(g, 3, 7)
+
+	a := s.RegeraBase(0, 2)
+	b := s.RegeraBase(0, 2)
+	for i := range a.Y {
+		if a.X[i] != b.X[i] || a.Y[i] != b.Y[i] {
+			t.Fatalf("#%d got (%v, %v); want (%v, %v)", i, b.X[i], b.Y[i], a.X[i], a.Y[i])
+		}
+	}
+
+	c := s.RegeraBase(0, 1)
+	if c.X[0] == a.X[0] {
+		t.Errorf("repetições 1 e 2 geraram o mesmo x = %v", a.X[0])
+	}
+
+	//com uma repetição a média é exatamente o overfit da base regerada
+	s.Repeticoes = 1
+	tab, err := s.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := s.Overfit(s.RegeraBase(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tab[0].Media; got != want {
+		t.Errorf("got media = %v; want %v", got, want)
+	}
+}
+
+func TestCheckpointRetoma(t *testing.T) {
+	g := Grade{Qf: []int{4, 6}, N: []int{20, 30}, Sigma: []float64{0.2}}
+	want, err := NovoSweep(g, 10, 3).Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	cp, err := AbreCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NovoSweep(g, 10, 3)
+	cp.Retoma(&s)
+	if _, err := s.Executa(); err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+
+	//retomada com as células 1 e 3 apagadas do checkpoint e uma linha final incompleta
+	dados, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linhas := strings.SplitAfter(string(dados), "\n")
+	parcial := linhas[0] + linhas[2] + `{"assinatura":`
+	if err := os.WriteFile(path, []byte(parcial), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err = AbreCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	s = NovoSweep(g, 10, 3)
+	cp.Retoma(&s)
+	if len(s.Concluidas) != 2 {
+		t.Fatalf("got %d células concluídas; want 2", len(s.Concluidas))
+	}
+	got, err := s.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("#%d got %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCheckpointUmaRepeticao(t *testing.T) {
+	//com uma repetição o erro padrão é NaN e precisa passar pelo JSON do checkpoint
+	g := Grade{Qf: []int{5}, N: []int{20}, Sigma: []float64{0}}
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	cp, err := AbreCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NovoSweep(g, 1, 1)
+	cp.Retoma(&s)
+	want, err := s.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+
+	cp, err = AbreCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	s = NovoSweep(g, 1, 1)
+	cp.Retoma(&s)
+	got, ok := s.Concluidas[0]
+	if !ok {
+		t.Fatal("célula 0 não foi gravada no checkpoint")
+	}
+	if got.Celula != want[0].Celula || got.Media != want[0].Media || !math.IsNaN(got.ErroPadrao) || got.Execucoes != 1 {
+		t.Errorf("got %+v; want %+v", got, want[0])
+	}
+}
+
+func TestLeComparacoes(t *testing.T) {
+	g := Grade{Qf: []int{4}, N: []int{15, 25}, Sigma: []float64{0, 0.5}}
+	tabela, err := NovoSweep(g, 5, 9).Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel := Relatorio{Comparacoes: []Comparacao{
+		{Hipoteses: Par{Simples: 2, Complexo: 10}, Tabela: tabela},
+		{Hipoteses: Par{Simples: 2, Complexo: 10, Regularizacao: Regularizacao{Lambda: 0.1, Penalidade: PenalidadeLegendre}}, Tabela: tabela[:2]},
+	}}
+
+	var sb strings.Builder
+	if err := rel.EscreveCSV(&sb); err != nil {
+		t.Fatal(err)
+	}
+	lidas, err := LeComparacoes(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lidas) != 2 {
+		t.Fatalf("got %d comparações; want 2", len(lidas))
+	}
+	for i, c := range rel.Comparacoes {
+		if lidas[i].Hipoteses != c.Hipoteses || len(lidas[i].Tabela) != len(c.Tabela) {
+			t.Fatalf("#%d got %v com %d células; want %v com %d", i, lidas[i].Hipoteses, len(lidas[i].Tabela), c.Hipoteses, len(c.Tabela))
+		}
+		for j := range c.Tabela {
+			if lidas[i].Tabela[j] != c.Tabela[j] {
+				t.Errorf("#%d.%d got %+v; want %+v", i, j, lidas[i].Tabela[j], c.Tabela[j])
+			}
+		}
+	}
+
+	sb.Reset()
+	if err := tabela.EscreveCSV(&sb); err != nil {
+		t.Fatal(err)
+	}
+	lidas, err = LeComparacoes(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lidas) != 1 || lidas[0].Hipoteses != (Par{}) || len(lidas[0].Tabela) != len(tabela) {
+		t.Errorf("tabela do sweep: got %+v", lidas)
+	}
+}
+
+func TestAoConcluirSerializado(t *testing.T) {
+	g := Grade{Qf: []int{3, 5, 8}, N: []int{20, 30}, Sigma: []float64{0, 0.5}}
+	s := NovoSweep(g, 4, 2)
+	s.Trabalhadores = 8
+	var ativas, chamadas int //sem sincronização própria: o detector de corridas acusa chamadas simultâneas
+	s.AoConcluir = func(i int, r Resultado) error {
+		ativas++
+		if ativas != 1 {
+			t.Errorf("célula %d: %d chamadas simultâneas de AoConcluir", i, ativas)
+		}
+		chamadas++
+		ativas--
+		return nil
+	}
+	if _, err := s.Executa(); err != nil {
+		t.Fatal(err)
+	}
+	if chamadas != len(g.Celulas()) {
+		t.Errorf("got %d chamadas; want %d", chamadas, len(g.Celulas()))
+	}
+}
+
+func TestSweepSemRepeticoes(t *testing.T) {
+	g := Grade{Qf: []int{5}, N: []int{20}, Sigma: []float64{0}}
+	if _, err := NovoSweep(g, 0, 1).Executa(); err == nil {
+		t.Error("sweep com 0 repetições aceito")
+	}
+}
+
+func TestSweepRuidoPadrao(t *testing.T) {
+	//um Sweep literal sem NovoRuido usa o ruído gaussiano, como NovoSweep
+	g := Grade{Qf: []int{5}, N: []int{20}, Sigma: []float64{0.5}}
+	s := Sweep{Grade: g, Repeticoes: 4, GrauSimples: 2, GrauComplexo: 10, Semente: 1}
+	got, err := s.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NovoSweep(g, 4, 1).Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != want[0] || s.assinatura() != NovoSweep(g, 4, 1).assinatura() {
+		t.Errorf("got %+v; want %+v", got[0], want[0])
+	}
+}
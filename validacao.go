@@ -0,0 +1,221 @@
+package lfdoverfitting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+//Ein erro dentro da amostra: média de (g(x_n) - y_n)^2, com g = sum_k ( g[k] * Legendre_k(x) ).
+//A série é avaliada diretamente; os monômios perdem a precisão de g nos graus altos.
+func Ein(b Base, g []float64) float64 {
+	soma := 0.0
+	for i := range b.X {
+		d := AvaliaLegendre(g, b.X[i]) - b.Y[i]
+		soma += d * d
+	}
+	return soma / float64(len(b.X))
+}
+
+//Candidato hipótese avaliada na validação: grau e weight decay opcional
+type Candidato struct {
+	Grau int `json:"grau"`
+	Regularizacao
+}
+
+//String descrição curta, ex.: "g10" ou "g10 ridge lambda=0.1"
+func (c Candidato) String() string {
+	if c.Lambda == 0 {
+		return fmt.Sprintf("g%d", c.Grau)
+	}
+	return fmt.Sprintf("g%d %s", c.Grau, c.Regularizacao)
+}
+
+//ajusta o candidato à base
+func (c Candidato) ajusta(b Base) (Ajuste, error) {
+	return AjustaRegularizado(b, c.Grau, c.Regularizacao)
+}
+
+//Holdout sorteia K pontos para validação, ajusta o grau nos N-K restantes e devolve o erro de validação
+func Holdout(rng *rand.Rand, b Base, grau int, k int) (float64, error) {
+	return holdout(b, Candidato{Grau: grau}, rng.Perm(len(b.X)), k)
+}
+
+//ValidacaoCruzada erro de validação cruzada com folds partes sorteadas; folds = N é o leave-one-out
+func ValidacaoCruzada(rng *rand.Rand, b Base, grau int, folds int) (float64, error) {
+	return validacaoCruzada(b, Candidato{Grau: grau}, rng.Perm(len(b.X)), folds)
+}
+
+//LeaveOneOut erro de validação cruzada leave-one-out exato, sem reajustar N vezes.
+//Para regressão linear (com ou sem weight decay) o resíduo com o ponto n fora do treino
+//é (y_n - g(x_n)) / (1 - H_nn), onde H é a matriz chapéu do ajuste em toda a base.
+//Um ponto interpolado (H_nn = 1) torna o erro +Inf.
+func LeaveOneOut(b Base, c Candidato) (float64, error) {
+	a, err := c.ajusta(b)
+	if err != nil {
+		return 0, err
+	}
+	soma := 0.0
+	for i, x := range b.X {
+		folga := 1 - a.Alavancas[i]
+		if folga < 1e-12 {
+			return math.Inf(1), nil
+		}
+		d := (b.Y[i] - AvaliaLegendre(a.Legendre, x)) / folga
+		soma += d * d
+	}
+	return soma / float64(len(b.X)), nil
+}
+
+//holdout valida nos K primeiros índices de perm e treina no restante
+func holdout(b Base, c Candidato, perm []int, k int) (float64, error) {
+	if k < 1 || k >= len(perm) {
+		return 0, fmt.Errorf("holdout: K = %d deve estar entre 1 e N-1 = %d", k, len(perm)-1)
+	}
+	a, err := c.ajusta(b.subBase(perm[k:]))
+	if err != nil {
+		return 0, err
+	}
+	return Ein(b.subBase(perm[:k]), a.Legendre), nil
+}
+
+//validacaoCruzada divide perm em folds partes de tamanhos quase iguais.
+//O resultado é a média do erro quadrático sobre todos os N pontos, cada um avaliado quando fora do treino.
+func validacaoCruzada(b Base, c Candidato, perm []int, folds int) (float64, error) {
+	n := len(perm)
+	if folds < 2 || folds > n {
+		return 0, fmt.Errorf("validação cruzada: folds = %d deve estar entre 2 e N = %d", folds, n)
+	}
+	soma := 0.0
+	for f := 0; f < folds; f++ {
+		ini, fim := f*n/folds, (f+1)*n/folds
+		treino := append(append([]int{}, perm[:ini]...), perm[fim:]...)
+		a, err := c.ajusta(b.subBase(treino))
+		if err != nil {
+			return 0, err
+		}
+		soma += Ein(b.subBase(perm[ini:fim]), a.Legendre) * float64(fim-ini)
+	}
+	return soma / float64(n), nil
+}
+
+//subBase base com os pontos de índices idx e o mesmo alvo e ruído
+func (b Base) subBase(idx []int) Base {
+	s := Base{Alvo: b.Alvo, Ruido: b.Ruido, X: make([]float64, len(idx)), Y: make([]float64, len(idx))}
+	for i, j := range idx {
+		s.X[i] = b.X[j]
+		s.Y[i] = b.Y[j]
+	}
+	return s
+}
+
+//métodos de validação aceitos por Validacao.Metodo
+const (
+	ValidacaoLOO     = "loo"
+	ValidacaoKFold   = "kfold"
+	ValidacaoHoldout = "holdout"
+)
+
+//Validacao método usado para estimar o erro fora da amostra de cada candidato
+type Validacao struct {
+	Metodo string //ValidacaoLOO (padrão), ValidacaoKFold ou ValidacaoHoldout
+	Folds  int    //partes do k-fold
+	K      int    //pontos no conjunto de validação do holdout
+}
+
+//AvaliacaoCandidato erros de um candidato na seleção de modelos
+type AvaliacaoCandidato struct {
+	Candidato
+	Ein  float64 //ajustado em toda a base
+	Ecv  float64 //estimativa de validação do método escolhido
+	Eout float64 //EoutEsperado do ajuste em toda a base, na escala de Ein e Ecv; NaN sem alvo
+}
+
+//Selecao resultado da seleção de modelos por validação
+type Selecao struct {
+	Validacao      Validacao
+	Candidatos     []AvaliacaoCandidato
+	Escolhido      int     //índice do candidato com menor Ecv
+	Oraculo        int     //índice do candidato com menor Eout, que só o alvo revela; -1 sem alvo
+	Arrependimento float64 //Eout(escolhido) - Eout(oráculo), o preço de escolher pela validação
+}
+
+//SelecionaModelo estima o erro de cada candidato pelo método v e escolhe o de menor estimativa.
+//O modelo final é reajustado em toda a base; com o alvo conhecido o relatório traz o Eout de cada
+//candidato e mostra quanto a validação protege contra o overfitting. Todos os candidatos usam a mesma
+//partição sorteada por rng.
+func SelecionaModelo(rng *rand.Rand, b Base, candidatos []Candidato, v Validacao) (Selecao, error) {
+	s := Selecao{Validacao: v}
+	if len(candidatos) == 0 {
+		return s, errors.New("seleção: nenhum candidato")
+	}
+	perm := rng.Perm(len(b.X))
+
+	menorCV, menorEout := math.Inf(1), math.Inf(1)
+	s.Escolhido, s.Oraculo = -1, -1
+	for i, c := range candidatos {
+		a, err := c.ajusta(b)
+		if err != nil {
+			return s, fmt.Errorf("%s: %v", c, err)
+		}
+		ac := AvaliacaoCandidato{Candidato: c, Ein: Ein(b, a.Legendre), Eout: EoutEsperado(b, a.Legendre)}
+
+		switch v.Metodo {
+		case "", ValidacaoLOO:
+			ac.Ecv, err = LeaveOneOut(b, c)
+		case ValidacaoKFold:
+			ac.Ecv, err = validacaoCruzada(b, c, perm, v.Folds)
+		case ValidacaoHoldout:
+			ac.Ecv, err = holdout(b, c, perm, v.K)
+		default:
+			err = fmt.Errorf("método de validação %q desconhecido; use %q, %q ou %q", v.Metodo, ValidacaoLOO, ValidacaoKFold, ValidacaoHoldout)
+		}
+		if err != nil {
+			return s, fmt.Errorf("%s: %v", c, err)
+		}
+
+		if ac.Ecv < menorCV || s.Escolhido < 0 {
+			menorCV = ac.Ecv
+			s.Escolhido = i
+		}
+		if ac.Eout < menorEout {
+			menorEout = ac.Eout
+			s.Oraculo = i
+		}
+		s.Candidatos = append(s.Candidatos, ac)
+	}
+
+	s.Arrependimento = math.NaN()
+	if s.Oraculo >= 0 {
+		s.Arrependimento = s.Candidatos[s.Escolhido].Eout - menorEout
+	}
+	return s, nil
+}
+
+//EscreveCSV grava uma linha por candidato com as colunas grau,lambda,penalidade,ein,ecv,eout,escolhido
+func (s Selecao) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"grau", "lambda", "penalidade", "ein", "ecv", "eout", "escolhido"}); err != nil {
+		return err
+	}
+	for i, c := range s.Candidatos {
+		linha := []string{
+			strconv.Itoa(c.Grau),
+			strconv.FormatFloat(c.Lambda, 'g', -1, 64),
+			c.Penalidade,
+			strconv.FormatFloat(c.Ein, 'g', -1, 64),
+			strconv.FormatFloat(c.Ecv, 'g', -1, 64),
+			strconv.FormatFloat(c.Eout, 'g', -1, 64),
+			strconv.FormatBool(i == s.Escolhido),
+		}
+		if err := cw.Write(linha); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,176 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLeaveOneOutExato(t *testing.T) {
+	b := GeraBase(NovoRNG(21), 8, 25, ruidoGaussiano(0.4))
+	perm := make([]int, len(b.X))
+	for i := range perm {
+		perm[i] = i
+	}
+	for _, c := range []Candidato{{Grau: 2}, {Grau: 10}, {Grau: 10, Regularizacao: Regularizacao{Lambda: 0.3, Penalidade: PenalidadeLegendre}}} {
+		got, err := LeaveOneOut(b, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := validacaoCruzada(b, c, perm, len(b.X))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-want) > 1e-8*want {
+			t.Errorf("%s: got %v; want %v reajustando N vezes", c, got, want)
+		}
+	}
+}
+
+func TestSelecionaModelo(t *testing.T) {
+	b := GeraBase(NovoRNG(4), 5, 40, ruidoGaussiano(1))
+	candidatos := []Candidato{{Grau: 0}, {Grau: 2}, {Grau: 5}, {Grau: 10}}
+	s, err := SelecionaModelo(NovoRNG(1), b, candidatos, Validacao{Metodo: ValidacaoKFold, Folds: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Candidatos) != len(candidatos) || s.Escolhido < 0 || s.Oraculo < 0 {
+		t.Fatalf("got %+v", s)
+	}
+	if s.Arrependimento < 0 {
+		t.Errorf("arrependimento %v negativo", s.Arrependimento)
+	}
+	if _, err := SelecionaModelo(NovoRNG(1), b, candidatos, Validacao{Metodo: "bootstrap"}); err == nil {
+		t.Errorf("método desconhecido aceito")
+	}
+}
+
+func TestSelecaoMesmaEscala(t *testing.T) {
+	//com o grau do alvo e muitos pontos, Ecv (LOO) e Eout estimam o mesmo sigma² + viés ~ 0
+	b := GeraBase(NovoRNG(12), 3, 2000, ruidoGaussiano(0.5))
+	s, err := SelecionaModelo(NovoRNG(1), b, []Candidato{{Grau: 3}}, Validacao{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := s.Candidatos[0]
+	if math.Abs(c.Eout-0.25) > 0.01 || math.Abs(c.Ecv-c.Eout) > 0.03 {
+		t.Errorf("ecv = %v, eout = %v; want ambos perto de 0.25", c.Ecv, c.Eout)
+	}
+}
+
+func TestAnalisaGrausSemEcv(t *testing.T) {
+	b := GeraBase(NovoRNG(3), 2, 20, ruidoGaussiano(0.1))
+	b.Y[0] = math.NaN() //contamina todos os Ecv
+	a, err := AnalisaGraus(NovoRNG(1), b, []int{1, 3}, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Selecionado != -1 {
+		t.Errorf("selecionado = %d com Ecv %v e %v; want -1", a.Selecionado, a.Graus[0].Ecv, a.Graus[1].Ecv)
+	}
+
+	b = GeraBase(NovoRNG(3), 2, 40, ruidoGaussiano(0.1))
+	if a, err = AnalisaGraus(NovoRNG(1), b, []int{1, 2, 3}, 8, 5); err != nil {
+		t.Fatal(err)
+	}
+	if a.Selecionado < 1 {
+		t.Errorf("selecionado = %d; want um dos candidatos", a.Selecionado)
+	}
+}
+
+func TestAnalisaGrausGrauAlto(t *testing.T) {
+	b := GeraBase(NovoRNG(7), 60, 300, ruidoGaussiano(0.1))
+	a, err := AnalisaGraus(NovoRNG(1), b, []int{60}, 30, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ein := a.Graus[0].Ein; ein > 0.01 {
+		t.Errorf("grau 60: ein = %v; want abaixo de sigma² = 0.01", ein)
+	}
+}
+
+func TestValidacaoGrauAlto(t *testing.T) {
+	//no grau 60 os monômios de g erram por ordens de grandeza; a validação tem de usar a série de legendre
+	b := GeraBase(NovoRNG(7), 60, 1000, ruidoGaussiano(0.1))
+	c := Candidato{Grau: 60}
+	a, err := c.ajusta(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ein, want := Ein(b, a.Legendre), a.Residuo*a.Residuo/float64(len(b.X)); math.Abs(ein-want) > 1e-9*want {
+		t.Errorf("ein = %v; want %v", ein, want)
+	}
+	s, err := SelecionaModelo(NovoRNG(1), b, []Candidato{c}, Validacao{Metodo: ValidacaoKFold, Folds: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ecv := s.Candidatos[0].Ecv; ecv > 1 {
+		t.Errorf("ecv = %v no grau 60; want da ordem de sigma² = 0.01", ecv)
+	}
+}
+
+func TestImportaCSV(t *testing.T) {
+	casos := []struct {
+		nome       string
+		csv        string
+		colX, colY string
+		x, y       []float64
+	}{
+		{"cabeçalho e nomes", "# medidas de campo\npressao, hora, temperatura\n1.5,0,20\n# sensor trocado\n2.5,1,30\n", "temperatura", "pressao", []float64{20, 30}, []float64{1.5, 2.5}},
+		{"cabeçalho sem nomes", "a,b,c\n1,2,3\n4,5,6\n", "", "", []float64{1, 4}, []float64{2, 5}},
+		{"sem cabeçalho", "1,2\n3,4\n", "", "", []float64{1, 3}, []float64{2, 4}},
+	}
+	for _, c := range casos {
+		b, err := ImportaCSV(strings.NewReader(c.csv), c.colX, c.colY)
+		if err != nil {
+			t.Errorf("%s: %v", c.nome, err)
+			continue
+		}
+		if !reflect.DeepEqual(b.X, c.x) || !reflect.DeepEqual(b.Y, c.y) || len(b.A) != 0 {
+			t.Errorf("%s: got x %v, y %v; want %v, %v", c.nome, b.X, b.Y, c.x, c.y)
+		}
+	}
+
+	invalidos := []struct {
+		nome string
+		csv  string
+		colX string
+	}{
+		{"coluna inexistente", "x,y\n1,2\n", "tempo"},
+		{"valor não numérico", "1,2\n3,abc\n", ""},
+		{"coluna faltando", "1,2\n3\n", ""},
+		{"sem pontos", "# vazio\nx,y\n", ""},
+	}
+	for _, c := range invalidos {
+		if _, err := ImportaCSV(strings.NewReader(c.csv), c.colX, "y"); err == nil {
+			t.Errorf("%s: aceito", c.nome)
+		}
+	}
+}
+
+func TestReescala(t *testing.T) {
+	b := Base{X: []float64{10, 15, 30}, Y: []float64{1, 2, 3}}
+	r, e, err := Reescala(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.X, []float64{-1, -0.5, 1}) || !reflect.DeepEqual(r.Y, b.Y) {
+		t.Errorf("got x %v, y %v", r.X, r.Y)
+	}
+	for i, x := range r.X {
+		if got := e.Inverte(x); math.Abs(got-b.X[i]) > 1e-12 {
+			t.Errorf("Inverte(%v) = %v; want %v", x, got, b.X[i])
+		}
+	}
+	if b.X[0] != 10 {
+		t.Error("Reescala alterou a base original")
+	}
+
+	if _, _, err := Reescala(Base{X: []float64{2, 2}, Y: []float64{0, 1}}); err == nil {
+		t.Error("x constante aceito")
+	}
+	if _, _, err := Reescala(Base{}); err == nil {
+		t.Error("base vazia aceita")
+	}
+}
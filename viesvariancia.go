@@ -0,0 +1,121 @@
+package lfdoverfitting
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//ViesVariancia experimento de decomposição do erro fora da amostra de uma hipótese de grau Grau.
+//Para cada um de Alvos alvos sorteados com grau Qf, gera Bases bases de N pontos com ruído NovoRuido(Sigma),
+//ajusta g_D em cada uma e calcula a hipótese média g-barra. Como as hipóteses estão na base de legendre,
+//viés E_x[(g-barra - f)²] e variância E_D E_x[(g_D - g-barra)²] saem exatos, sem sortear x.
+type ViesVariancia struct {
+	Qf            int
+	N             int
+	Sigma         float64
+	Grau          int
+	Regularizacao Regularizacao //weight decay opcional da hipótese
+
+	Bases int //bases por alvo
+	Alvos int //alvos sorteados; os resultados são médias sobre eles. 0 usa 1
+
+	NovoRuido func(sigma float64) Ruido //modelo de ruído; nil usa o gaussiano
+	Semente   int64                     //o alvo a e a base d de cada alvo usam DerivaSemente(Semente, a[, d])
+}
+
+//Decomposicao do erro fora da amostra esperado, E_D[E_out(g_D)] = Vies + Variancia + Ruido.
+//Todas as parcelas são esperanças com x uniforme em [-1;1], na escala de Ruido.Energia() = sigma²
+//(metade da escala de EoutLegendre, que integra sobre [-1;1]).
+type Decomposicao struct {
+	Grau      int
+	Vies      float64   //E_x[(g-barra(x) - f(x))²]
+	Variancia float64   //E_D E_x[(g_D(x) - g-barra(x))²]
+	Ruido     float64   //energia do ruído estocástico
+	Eout      float64   //E_D E_{x,y}[(g_D(x) - y)²], medido diretamente em cada g_D
+	GBarra    []float64 //coeficientes de legendre de g-barra do primeiro alvo
+}
+
+//Executa o experimento
+func (v ViesVariancia) Executa() (Decomposicao, error) {
+	d := Decomposicao{Grau: v.Grau}
+	if v.Bases < 2 {
+		return d, fmt.Errorf("viés e variância: bases = %d deve ser pelo menos 2", v.Bases)
+	}
+	if v.N < 1 {
+		return d, errors.New("viés e variância: N deve ser positivo")
+	}
+	if v.Grau < 0 {
+		return d, fmt.Errorf("viés e variância: grau %d negativo", v.Grau)
+	}
+	alvos := v.Alvos
+	if alvos < 1 {
+		alvos = 1
+	}
+	novoRuido := v.NovoRuido
+	if novoRuido == nil {
+		novoRuido = ruidoGaussiano
+	}
+	ruido := novoRuido(v.Sigma)
+	d.Ruido = ruido.Energia()
+
+	for a := 0; a < alvos; a++ {
+		alvo := GeraAlvo(NovoRNG(DerivaSemente(v.Semente, a)), v.Qf)
+
+		gs := make([][]float64, v.Bases)
+		gBarra := make([]float64, v.Grau+1)
+		for j := range gs {
+			b := AmostraBase(NovoRNG(DerivaSemente(v.Semente, a, j)), alvo, v.N, ruido)
+			aj, err := AjustaRegularizado(b, v.Grau, v.Regularizacao)
+			if err != nil {
+				return d, err
+			}
+			gs[j] = aj.Legendre
+			for q, c := range aj.Legendre {
+				gBarra[q] += c / float64(v.Bases)
+			}
+		}
+		if a == 0 {
+			d.GBarra = gBarra
+		}
+
+		vies := EoutLegendre(alvo.A, gBarra) / 2
+		variancia, eout := 0.0, 0.0
+		for _, g := range gs {
+			variancia += EoutLegendre(g, gBarra) / 2
+			eout += EoutLegendre(alvo.A, g) / 2
+		}
+		d.Vies += vies / float64(alvos)
+		d.Variancia += variancia / float64(v.Bases*alvos)
+		d.Eout += eout / float64(v.Bases*alvos)
+	}
+	d.Eout += d.Ruido
+	return d, nil
+}
+
+//Decomposicoes resultados do experimento para vários graus de hipótese
+type Decomposicoes []Decomposicao
+
+//EscreveCSV grava uma linha por grau com as colunas grau,vies,variancia,ruido,eout
+func (ds Decomposicoes) EscreveCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"grau", "vies", "variancia", "ruido", "eout"}); err != nil {
+		return err
+	}
+	for _, d := range ds {
+		linha := []string{
+			strconv.Itoa(d.Grau),
+			strconv.FormatFloat(d.Vies, 'g', -1, 64),
+			strconv.FormatFloat(d.Variancia, 'g', -1, 64),
+			strconv.FormatFloat(d.Ruido, 'g', -1, 64),
+			strconv.FormatFloat(d.Eout, 'g', -1, 64),
+		}
+		if err := cw.Write(linha); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,68 @@
+package lfdoverfitting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestViesVariancia(t *testing.T) {
+	v := ViesVariancia{Qf: 10, N: 20, Sigma: 0.5, Bases: 200, Alvos: 2, Semente: 3}
+	var ds Decomposicoes
+	for _, grau := range []int{2, 10} {
+		v.Grau = grau
+		d, err := v.Executa()
+		if err != nil {
+			t.Fatal(err)
+		}
+		//com g-barra igual à média amostral a decomposição é uma identidade
+		if soma := d.Vies + d.Variancia + d.Ruido; math.Abs(soma-d.Eout) > 1e-9*d.Eout {
+			t.Errorf("grau %d: vies + variancia + ruido = %v; want eout %v", grau, soma, d.Eout)
+		}
+		ds = append(ds, d)
+	}
+	if ds[1].Variancia <= ds[0].Variancia {
+		t.Errorf("variância de H10 (%v) não supera a de H2 (%v) com N = 20", ds[1].Variancia, ds[0].Variancia)
+	}
+}
+
+func TestViesVarianciaMonteCarlo(t *testing.T) {
+	//E_out medido em pontos novos (x, y), sorteados independentemente das bases de ajuste
+	v := ViesVariancia{Qf: 5, N: 30, Sigma: 0.5, Grau: 3, Bases: 50, Semente: 8}
+	d, err := v.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alvo := GeraAlvo(NovoRNG(DerivaSemente(v.Semente, 0)), v.Qf)
+	ruido := ruidoGaussiano(v.Sigma)
+	teste := NovoRNG(99)
+	eout := 0.0
+	for j := 0; j < v.Bases; j++ {
+		aj, err := AjustaLegendre(AmostraBase(NovoRNG(DerivaSemente(v.Semente, 0, j)), alvo, v.N, ruido), v.Grau)
+		if err != nil {
+			t.Fatal(err)
+		}
+		eout += Ein(AmostraBase(teste, alvo, 20000, ruido), aj.Legendre) / float64(v.Bases)
+	}
+	if math.Abs(eout-d.Eout) > 0.02*d.Eout {
+		t.Errorf("eout = %v; want %v medido em pontos novos", d.Eout, eout)
+	}
+}
+
+func TestViesNuloComAlvoNoModelo(t *testing.T) {
+	//com Grau >= Qf os mínimos quadrados não têm viés: g-barra só se afasta de f pelo erro da média de Bases ajustes
+	v := ViesVariancia{Qf: 2, N: 20, Sigma: 0.5, Grau: 5, Bases: 400, Alvos: 3, Semente: 5}
+	d, err := v.Executa()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Vies > 5*d.Variancia/float64(v.Bases) {
+		t.Errorf("viés = %v com grau 5 >= qf 2; want perto de 0 (variância/bases = %v)", d.Vies, d.Variancia/float64(v.Bases))
+	}
+}
+
+func TestViesVarianciaGrauNegativo(t *testing.T) {
+	v := ViesVariancia{Qf: 2, N: 20, Sigma: 0.5, Grau: -2, Bases: 10}
+	if _, err := v.Executa(); err == nil {
+		t.Error("grau -2 aceito")
+	}
+}